@@ -0,0 +1,151 @@
+package nysenateapi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ActionType string
+
+const (
+	ActionUnknown            ActionType = "unknown"
+	ActionReferral           ActionType = "referral"
+	ActionAmendment          ActionType = "amendment"
+	ActionPrintNumber        ActionType = "print number"
+	ActionReport             ActionType = "report"
+	ActionThirdReading       ActionType = "third reading"
+	ActionRestored           ActionType = "restored"
+	ActionPassage            ActionType = "passage"
+	ActionDefeated           ActionType = "defeated"
+	ActionHeld               ActionType = "held"
+	ActionSubstitution       ActionType = "substitution"
+	ActionDelivery           ActionType = "delivery"
+	ActionReturned           ActionType = "returned"
+	ActionRecall             ActionType = "recall"
+	ActionSignature          ActionType = "signature"
+	ActionVeto               ActionType = "veto"
+	ActionApprovalMemo       ActionType = "approval memo"
+	ActionEnactingStricken   ActionType = "enacting clause stricken"
+	ActionHomeRule           ActionType = "home rule request"
+	ActionMessageOfNecessity ActionType = "message of necessity"
+	ActionMotion             ActionType = "motion"
+)
+
+// ActionEvent is an Action classified into a typed event with any arguments extracted from the text
+type ActionEvent struct {
+	Action
+	Type ActionType `json:"Type"`
+	// Committee is the committee referred to, reported to, or held in
+	Committee string `json:"Committee,omitempty"`
+	// PrintNo is the new print number for amendments or the other bill in a substitution
+	PrintNo string `json:"PrintNo,omitempty"`
+	// Replaces is set for "SUBSTITUTED FOR"; PrintNo is the bill this bill replaced
+	Replaces bool `json:"Replaces,omitempty"`
+	// Target is the chamber (SENATE, ASSEMBLY) or GOVERNOR a bill was delivered to, returned to, recalled from or passed
+	Target   string `json:"Target,omitempty"`
+	Calendar int    `json:"Calendar,omitempty"`
+	Chapter  int    `json:"Chapter,omitempty"`
+	Memo     int    `json:"Memo,omitempty"`
+}
+
+type actionRule struct {
+	pattern *regexp.Regexp
+	t       ActionType
+	// arg names the field populated from the first submatch
+	arg string
+}
+
+const printNoPattern = `([A-Z]?\d+[A-Z]?)`
+
+func rule(t ActionType, arg, pattern string) actionRule {
+	return actionRule{pattern: regexp.MustCompile(pattern), t: t, arg: arg}
+}
+
+// actionRules are matched in order against the upper cased action text.
+// Senate actions are upper case; Assembly actions are lower case.
+var actionRules = []actionRule{
+	rule(ActionSignature, "chapter", `^SIGNED CHAP\.?\s*(\d+)`),
+	rule(ActionVeto, "memo", `^VETOED MEMO\.?\s*(\d+)`),
+	rule(ActionVeto, "", `^VETOED`),
+	rule(ActionApprovalMemo, "memo", `^APPROVAL MEMO\.?\s*(\d+)`),
+	rule(ActionDelivery, "target", `^DELIVERED TO (GOVERNOR|SENATE|ASSEMBLY)`),
+	rule(ActionReturned, "target", `^RETURNED TO (SENATE|ASSEMBLY)`),
+	rule(ActionRecall, "target", `^RECALLED FROM (GOVERNOR|SENATE|ASSEMBLY)`),
+	rule(ActionSubstitution, "printNo", `^SUBSTITUTED BY `+printNoPattern),
+	rule(ActionSubstitution, "replaces", `^SUBSTITUTED FOR `+printNoPattern),
+	rule(ActionAmendment, "committee", `^AMEND(?:ED)? (?:\(T\) )?AND RECOMMIT(?:TED)? TO (.+)`),
+	rule(ActionAmendment, "printNo", `^AMENDED ON THIRD READING (?:\(T\) )?`+printNoPattern),
+	rule(ActionAmendment, "printNo", `^AMENDED (?:\(T\) )?`+printNoPattern+`$`),
+	rule(ActionPrintNumber, "printNo", `^PRINT NUMBER `+printNoPattern),
+	rule(ActionEnactingStricken, "", `ENACTING CLAUSE STRICKEN`),
+	rule(ActionRestored, "", `RESTORED TO THIRD READING`),
+	rule(ActionReport, "committee", `^REPORTED (?:AND COMMITTED|REFERRED) TO (.+)`),
+	rule(ActionReport, "calendar", `^(?:\d+(?:ST|ND|RD|TH) )?REPORT CAL\.?\s*(\d*)`),
+	rule(ActionReport, "calendar", `^RULES REPORT CAL\.?\s*(\d+)`),
+	rule(ActionReport, "", `^REPORTED`),
+	rule(ActionThirdReading, "calendar", `^(?:ADVANCED|ORDERED) TO THIRD READING(?: RULES)? CAL\.?\s*(\d+)`),
+	rule(ActionThirdReading, "", `^(?:ADVANCED|ORDERED) TO THIRD READING`),
+	rule(ActionReferral, "committee", `^(?:REFERRED|COMMITTED|RECOMMITTED|RECOMMIT) TO (.+)`),
+	rule(ActionPassage, "target", `^PASSED (SENATE|ASSEMBLY)`),
+	rule(ActionHeld, "committee", `^HELD FOR CONSIDERATION IN (.+)`),
+	rule(ActionHeld, "", `^(?:HELD FOR CONSIDERATION|TABLED)`),
+	rule(ActionDefeated, "committee", `^DEFEATED IN (.+)`),
+	rule(ActionDefeated, "", `^(?:LOST|DEFEATED)`),
+	rule(ActionHomeRule, "", `^HOME RULE REQUEST`),
+	rule(ActionMessageOfNecessity, "", `^MESSAGE OF NECESSITY`),
+	rule(ActionMotion, "", `^MOTION `),
+}
+
+var (
+	actionSpaces    = regexp.MustCompile(`\s+`)
+	bareNumber      = regexp.MustCompile(`^\d+[A-Z]?$`)
+	chamberPrefixes = map[string]string{"SENATE": "S", "ASSEMBLY": "A"}
+)
+
+// ClassifyAction converts an Action into a typed ActionEvent
+func ClassifyAction(a Action) ActionEvent {
+	e := ActionEvent{Action: a, Type: ActionUnknown}
+	text := strings.TrimSpace(actionSpaces.ReplaceAllString(strings.ToUpper(a.Text), " "))
+	for _, r := range actionRules {
+		m := r.pattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		e.Type = r.t
+		var arg string
+		if len(m) > 1 {
+			arg = strings.TrimSpace(m[1])
+		}
+		switch r.arg {
+		case "chapter":
+			e.Chapter, _ = strconv.Atoi(arg)
+		case "memo":
+			e.Memo, _ = strconv.Atoi(arg)
+		case "calendar":
+			e.Calendar, _ = strconv.Atoi(arg)
+		case "target":
+			e.Target = arg
+		case "committee":
+			e.Committee = arg
+		case "printNo", "replaces":
+			// amended print numbers omit the chamber prefix i.e. "AMENDED ON THIRD READING 2304B"
+			if bareNumber.MatchString(arg) {
+				arg = chamberPrefixes[strings.ToUpper(a.Chamber)] + arg
+			}
+			e.PrintNo = arg
+			e.Replaces = r.arg == "replaces"
+		}
+		return e
+	}
+	return e
+}
+
+// ActionEvents classifies each of the bill's actions
+func (b Bill) ActionEvents() []ActionEvent {
+	var o []ActionEvent
+	for _, a := range b.Actions {
+		o = append(o, ClassifyAction(a))
+	}
+	return o
+}
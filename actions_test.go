@@ -0,0 +1,87 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyAction(t *testing.T) {
+	type testCase struct {
+		chamber string
+		text    string
+		want    ActionEvent
+	}
+	tests := []testCase{
+		// Senate
+		{"SENATE", "REFERRED TO CITIES 1", ActionEvent{Type: ActionReferral, Committee: "CITIES 1"}},
+		{"SENATE", "REFERRED TO RULES", ActionEvent{Type: ActionReferral, Committee: "RULES"}},
+		{"SENATE", "COMMITTED TO RULES", ActionEvent{Type: ActionReferral, Committee: "RULES"}},
+		{"SENATE", "RECOMMITTED TO HEALTH", ActionEvent{Type: ActionReferral, Committee: "HEALTH"}},
+		{"SENATE", "AMEND AND RECOMMIT TO HEALTH", ActionEvent{Type: ActionAmendment, Committee: "HEALTH"}},
+		{"SENATE", "AMEND (T) AND RECOMMIT TO FINANCE", ActionEvent{Type: ActionAmendment, Committee: "FINANCE"}},
+		{"SENATE", "AMENDED ON THIRD READING 2304B", ActionEvent{Type: ActionAmendment, PrintNo: "S2304B"}},
+		{"SENATE", "AMENDED ON THIRD READING (T) 2304B", ActionEvent{Type: ActionAmendment, PrintNo: "S2304B"}},
+		{"SENATE", "PRINT NUMBER 2304A", ActionEvent{Type: ActionPrintNumber, PrintNo: "S2304A"}},
+		{"SENATE", "1ST REPORT CAL.120", ActionEvent{Type: ActionReport, Calendar: 120}},
+		{"SENATE", "2ND REPORT CAL.", ActionEvent{Type: ActionReport}},
+		{"SENATE", "REPORTED AND COMMITTED TO FINANCE", ActionEvent{Type: ActionReport, Committee: "FINANCE"}},
+		{"SENATE", "ADVANCED TO THIRD READING", ActionEvent{Type: ActionThirdReading}},
+		{"SENATE", "ORDERED TO THIRD READING CAL.1234", ActionEvent{Type: ActionThirdReading, Calendar: 1234}},
+		{"SENATE", "PASSED SENATE", ActionEvent{Type: ActionPassage, Target: "SENATE"}},
+		{"SENATE", "DELIVERED TO ASSEMBLY", ActionEvent{Type: ActionDelivery, Target: "ASSEMBLY"}},
+		{"SENATE", "RETURNED TO SENATE", ActionEvent{Type: ActionReturned, Target: "SENATE"}},
+		{"SENATE", "RECALLED FROM ASSEMBLY", ActionEvent{Type: ActionRecall, Target: "ASSEMBLY"}},
+		{"SENATE", "RECALLED FROM GOVERNOR", ActionEvent{Type: ActionRecall, Target: "GOVERNOR"}},
+		{"SENATE", "SUBSTITUTED BY A1234", ActionEvent{Type: ActionSubstitution, PrintNo: "A1234"}},
+		{"SENATE", "SUBSTITUTED FOR S5678A", ActionEvent{Type: ActionSubstitution, PrintNo: "S5678A", Replaces: true}},
+		{"SENATE", "DELIVERED TO GOVERNOR", ActionEvent{Type: ActionDelivery, Target: "GOVERNOR"}},
+		{"SENATE", "SIGNED CHAP.45", ActionEvent{Type: ActionSignature, Chapter: 45}},
+		{"SENATE", "SIGNED CHAP. 580", ActionEvent{Type: ActionSignature, Chapter: 580}},
+		{"SENATE", "VETOED MEMO.120", ActionEvent{Type: ActionVeto, Memo: 120}},
+		{"SENATE", "APPROVAL MEMO.12", ActionEvent{Type: ActionApprovalMemo, Memo: 12}},
+		{"SENATE", "VOTE RECONSIDERED - RESTORED TO THIRD READING", ActionEvent{Type: ActionRestored}},
+		{"SENATE", "RESTORED TO THIRD READING", ActionEvent{Type: ActionRestored}},
+		{"SENATE", "RECOMMIT, ENACTING CLAUSE STRICKEN", ActionEvent{Type: ActionEnactingStricken}},
+		{"SENATE", "HOME RULE REQUEST", ActionEvent{Type: ActionHomeRule}},
+		{"SENATE", "MESSAGE OF NECESSITY - 3 DAY MESSAGE", ActionEvent{Type: ActionMessageOfNecessity}},
+		{"SENATE", "LOST", ActionEvent{Type: ActionDefeated}},
+		{"SENATE", "TABLED", ActionEvent{Type: ActionHeld}},
+		{"SENATE", "OPINION REFERRED TO JUDICIARY", ActionEvent{Type: ActionUnknown}},
+
+		// Assembly
+		{"ASSEMBLY", "referred to codes", ActionEvent{Type: ActionReferral, Committee: "CODES"}},
+		{"ASSEMBLY", "referred to ways and means", ActionEvent{Type: ActionReferral, Committee: "WAYS AND MEANS"}},
+		{"ASSEMBLY", "amend and recommit to transportation", ActionEvent{Type: ActionAmendment, Committee: "TRANSPORTATION"}},
+		{"ASSEMBLY", "amend (t) and recommit to codes", ActionEvent{Type: ActionAmendment, Committee: "CODES"}},
+		{"ASSEMBLY", "print number 1610a", ActionEvent{Type: ActionPrintNumber, PrintNo: "A1610A"}},
+		{"ASSEMBLY", "amended on third reading 1610b", ActionEvent{Type: ActionAmendment, PrintNo: "A1610B"}},
+		{"ASSEMBLY", "reported referred to ways and means", ActionEvent{Type: ActionReport, Committee: "WAYS AND MEANS"}},
+		{"ASSEMBLY", "reported referred to rules", ActionEvent{Type: ActionReport, Committee: "RULES"}},
+		{"ASSEMBLY", "reported", ActionEvent{Type: ActionReport}},
+		{"ASSEMBLY", "rules report cal.439", ActionEvent{Type: ActionReport, Calendar: 439}},
+		{"ASSEMBLY", "ordered to third reading rules cal.439", ActionEvent{Type: ActionThirdReading, Calendar: 439}},
+		{"ASSEMBLY", "advanced to third reading cal.120", ActionEvent{Type: ActionThirdReading, Calendar: 120}},
+		{"ASSEMBLY", "passed assembly", ActionEvent{Type: ActionPassage, Target: "ASSEMBLY"}},
+		{"ASSEMBLY", "delivered to senate", ActionEvent{Type: ActionDelivery, Target: "SENATE"}},
+		{"ASSEMBLY", "returned to assembly", ActionEvent{Type: ActionReturned, Target: "ASSEMBLY"}},
+		{"ASSEMBLY", "recalled from senate", ActionEvent{Type: ActionRecall, Target: "SENATE"}},
+		{"ASSEMBLY", "substituted by s2304", ActionEvent{Type: ActionSubstitution, PrintNo: "S2304"}},
+		{"ASSEMBLY", "held for consideration in codes", ActionEvent{Type: ActionHeld, Committee: "CODES"}},
+		{"ASSEMBLY", "defeated in codes", ActionEvent{Type: ActionDefeated, Committee: "CODES"}},
+		{"ASSEMBLY", "enacting clause stricken", ActionEvent{Type: ActionEnactingStricken}},
+		{"ASSEMBLY", "motion to discharge lost", ActionEvent{Type: ActionMotion}},
+		{"ASSEMBLY", "vote reconsidered - restored to third reading", ActionEvent{Type: ActionRestored}},
+		{"ASSEMBLY", "home rule request", ActionEvent{Type: ActionHomeRule}},
+		{"ASSEMBLY", "delivered  to   governor", ActionEvent{Type: ActionDelivery, Target: "GOVERNOR"}},
+		{"ASSEMBLY", "signed chap.45", ActionEvent{Type: ActionSignature, Chapter: 45}},
+		{"ASSEMBLY", "vetoed memo.120", ActionEvent{Type: ActionVeto, Memo: 120}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			a := Action{Text: tc.text, Chamber: tc.chamber}
+			tc.want.Action = a
+			assert.Equal(t, tc.want, ClassifyAction(a))
+		})
+	}
+}
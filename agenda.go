@@ -0,0 +1,167 @@
+package nysenateapi
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+type CommitteeMeeting struct {
+	AgendaNumber int `json:"AgendaNumber,omitempty"`
+	AgendaYear   int `json:"AgendaYear,omitempty"`
+	// Addendum is the latest agenda addendum merged into the meeting
+	Addendum  string `json:"Addendum,omitempty"`
+	Chamber   string `json:"Chamber"`
+	Committee string `json:"Committee"`
+	// Hearing is set for Assembly public hearings; Subject is the hearing topic
+	Hearing  bool   `json:"Hearing,omitempty"`
+	Subject  string `json:"Subject,omitempty"`
+	Chair    string `json:"Chair,omitempty"`
+	Location string `json:"Location,omitempty"`
+	// Time is the local (America/New_York) meeting time
+	Time civil.DateTime `json:"Time"`
+	// TimeUnknown is set when the meeting has a date but no set time i.e. Assembly meetings "Off the Floor"
+	TimeUnknown bool            `json:"TimeUnknown,omitempty"`
+	Notes       string          `json:"Notes,omitempty"`
+	Bills       []BillReference `json:"Bills,omitempty"`
+}
+
+type SessionDay struct {
+	Date           civil.Date      `json:"Date"`
+	Year           int             `json:"Year"`
+	CalendarNumber int             `json:"CalendarNumber"`
+	Bills          []BillReference `json:"Bills,omitempty"`
+}
+
+// CommitteeMeetings returns Senate committee meetings (and their agendas) between from and to.
+//
+// The addenda of an agenda are merged into a single meeting with the bills from every addendum.
+func (a *API) CommitteeMeetings(ctx context.Context, from, to time.Time) ([]CommitteeMeeting, error) {
+	resp, err := a.api.GetCommitteeMeetings(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var out []CommitteeMeeting
+	for _, c := range resp.Result.Items {
+		if m, ok := newCommitteeMeeting(c); ok {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// newCommitteeMeeting merges the addenda of an agenda. Meeting details are taken from the latest
+// addendum that has them; bills are the union of bills on all addenda.
+func newCommitteeMeeting(c verboseapi.CommitteeAgenda) (CommitteeMeeting, bool) {
+	if len(c.Addenda.Items) == 0 {
+		return CommitteeMeeting{}, false
+	}
+	m := CommitteeMeeting{
+		AgendaNumber: c.AgendaID.Number,
+		AgendaYear:   c.AgendaID.Year,
+		Chamber:      c.CommitteeID.Chamber,
+		Committee:    c.CommitteeID.Name,
+	}
+	seen := make(map[BillReference]bool)
+	for _, a := range c.Addenda.Items {
+		m.Addendum = a.AddendumID
+		if a.Meeting.Chair != "" {
+			m.Chair = a.Meeting.Chair
+		}
+		if a.Meeting.Location != "" {
+			m.Location = a.Meeting.Location
+		}
+		if a.Meeting.MeetingDateTime != "" {
+			m.Time = civil.DateTimeOf(parseTime(a.Meeting.MeetingDateTime))
+			m.TimeUnknown = len(a.Meeting.MeetingDateTime) == len("2006-01-02")
+		}
+		if a.Meeting.Notes != "" {
+			m.Notes = a.Meeting.Notes
+		}
+		for _, b := range a.Bills.Items {
+			ref := BillReference{
+				PrintNo: b.BillID.BasePrintNo,
+				Session: b.BillID.Session,
+			}
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			m.Bills = append(m.Bills, ref)
+		}
+	}
+	return m, true
+}
+
+// AssemblyMeetings returns upcoming Assembly committee meetings and public hearings from nyassembly.gov
+// ordered by time. Assembly meetings don't have an AgendaNumber.
+func (a *API) AssemblyMeetings(ctx context.Context) ([]CommitteeMeeting, error) {
+	agendas, err := a.api.AssemblyCommitteeAgendas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hearings, err := a.api.AssemblyHearings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []CommitteeMeeting
+	for _, m := range append(agendas, hearings...) {
+		if cm, ok := newCommitteeMeeting(m.CommitteeAgenda); ok {
+			cm.Hearing = m.Hearing
+			cm.Subject = m.Subject
+			out = append(out, cm)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+// SessionDays returns the days with a floor calendar in the given year.
+func (a *API) SessionDays(ctx context.Context, year int) ([]SessionDay, error) {
+	var out []SessionDay
+	offset := 1
+	for {
+		resp, err := a.api.GetCalendars(ctx, year, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return out, nil
+		}
+		for _, c := range resp.Result.Items {
+			out = append(out, newSessionDay(c))
+		}
+		if resp.OffsetEnd >= resp.Total || len(resp.Result.Items) == 0 {
+			return out, nil
+		}
+		offset = resp.OffsetEnd + 1
+	}
+}
+
+func newSessionDay(c verboseapi.Calendar) SessionDay {
+	d := SessionDay{
+		Date:           civil.DateOf(parseTime(c.CalDate)),
+		Year:           c.Year,
+		CalendarNumber: c.CalendarNumber,
+	}
+	var sections []string
+	for section := range c.FloorCalendar.EntriesBySection {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	seen := make(map[BillReference]bool)
+	for _, section := range sections {
+		for _, e := range c.FloorCalendar.EntriesBySection[section].Items {
+			b := BillReference{PrintNo: e.BasePrintNo, Session: e.Session}
+			if seen[b] {
+				continue
+			}
+			seen[b] = true
+			d.Bills = append(d.Bills, b)
+		}
+	}
+	return d
+}
@@ -0,0 +1,40 @@
+package nysenateapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommitteeMeeting(t *testing.T) {
+	var c verboseapi.CommitteeAgenda
+	err := json.Unmarshal([]byte(`{
+		"agendaId": {"number": 3, "year": 2024},
+		"committeeId": {"chamber": "SENATE", "name": "Cities 1"},
+		"addenda": {"items": [
+			{"addendumId": "", "meeting": {"chair": "Robert Jackson", "location": "Room 124 CAP", "meetingDateTime": "2024-01-23T11:00"},
+				"bills": {"items": [{"billId": {"basePrintNo": "S1234", "session": 2023}}, {"billId": {"basePrintNo": "S5678", "session": 2023}}]}},
+			{"addendumId": "A", "meeting": {"chair": "Robert Jackson", "location": "Room 124 CAP", "meetingDateTime": "2024-01-23T11:00"},
+				"bills": {"items": [{"billId": {"basePrintNo": "S5678", "session": 2023}}, {"billId": {"basePrintNo": "S9999", "session": 2023}}]}}
+		], "size": 2}
+	}`), &c)
+	require.NoError(t, err)
+
+	m, ok := newCommitteeMeeting(c)
+	require.True(t, ok)
+	assert.Equal(t, "A", m.Addendum)
+	assert.Equal(t, "Cities 1", m.Committee)
+	assert.Equal(t, civil.DateTime{Date: date(2024, 1, 23), Time: civil.Time{Hour: 11}}, m.Time)
+	assert.Equal(t, []BillReference{
+		{PrintNo: "S1234", Session: 2023},
+		{PrintNo: "S5678", Session: 2023},
+		{PrintNo: "S9999", Session: 2023},
+	}, m.Bills)
+
+	_, ok = newCommitteeMeeting(verboseapi.CommitteeAgenda{})
+	assert.False(t, ok)
+}
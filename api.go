@@ -2,32 +2,27 @@ package nysenateapi
 
 import (
 	"context"
-	"fmt"
-	"sync"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/jehiah/nysenateapi/verboseapi"
 )
 
 type API struct {
-	api *verboseapi.NYSenateAPI
-
-	mutex           sync.Mutex
-	assemblyMembers map[int][]verboseapi.MemberEntry
+	api     *verboseapi.NYSenateAPI
+	members *memberCache
 }
 
 func NewWithVerboseAPI(api *verboseapi.NYSenateAPI) *API {
 	return &API{
-		api:             api,
-		assemblyMembers: make(map[int][]verboseapi.MemberEntry),
+		api:     api,
+		members: newMemberCache(api),
 	}
 }
 
 func NewAPI(token string) *API {
-	return &API{
-		api:             verboseapi.NewAPI(token),
-		assemblyMembers: make(map[int][]verboseapi.MemberEntry),
-	}
+	return NewWithVerboseAPI(verboseapi.NewAPI(token))
 }
 
 func (a *API) GetBill(ctx context.Context, session, printNo string) (*Bill, error) {
@@ -38,37 +33,61 @@ func (a *API) GetBill(ctx context.Context, session, printNo string) (*Bill, erro
 	if bill == nil {
 		return nil, nil
 	}
+	return a.withVotes(ctx, newBill(bill))
+}
 
-	out := newBill(bill)
-
+// withVotes adds nyassembly.gov votes to Assembly bills and reconciles votes from each source
+func (a *API) withVotes(ctx context.Context, out *Bill) (*Bill, error) {
 	if out.Chamber == "ASSEMBLY" {
-		members, err := a.getAssemblyMembers(ctx, bill.Session)
+		resolver, err := a.getAssemblyResolver(ctx, out.Session)
 		if err != nil {
 			return nil, err
 		}
-		votes, err := a.api.AssemblyVotes(ctx, members, session, printNo)
+		votes, err := a.api.ResolveAssemblyVotes(ctx, resolver, strconv.Itoa(out.Session), out.PrintNo)
 		if err != nil {
 			return nil, err
 		}
-		out.Votes = append(out.Votes, newVotes(votes)...)
+		out.Votes = append(out.Votes, newVotes(votes, SourceAssembly, out.Version)...)
 	}
-
+	out.Votes, out.VoteConflicts = ReconcileVotes(out.Votes)
 	return out, nil
 }
 
-func (a *API) getAssemblyMembers(ctx context.Context, session int) ([]verboseapi.MemberEntry, error) {
-	a.mutex.Lock()
-	defer a.mutex.Unlock()
-	if members, ok := a.assemblyMembers[session]; ok {
-		return members, nil
+// GetVotes returns the votes on a bill. Unless allVersions is set only votes on the active
+// amendment are returned.
+func (a *API) GetVotes(ctx context.Context, session, printNo string, allVersions bool) ([]Vote, error) {
+	bill, err := a.GetBill(ctx, session, printNo)
+	if err != nil || bill == nil {
+		return nil, err
+	}
+	if allVersions {
+		return bill.Votes, nil
 	}
-	sessionStr := fmt.Sprintf("%d", session)
-	members, err := a.api.GetMembers(ctx, sessionStr, verboseapi.AssemblyChamber)
+	return bill.ActiveVotes(), nil
+}
+
+// getAssemblyResolver matches Assembly member names against members of the session and the
+// previous session
+func (a *API) getAssemblyResolver(ctx context.Context, session int) (*verboseapi.MemberResolver, error) {
+	members, err := a.Members(ctx, session, verboseapi.AssemblyChamber)
 	if err != nil {
 		return nil, err
 	}
-	a.assemblyMembers[session] = members
-	return members, nil
+	previous, err := a.Members(ctx, session-2, verboseapi.AssemblyChamber)
+	if err != nil {
+		// previous session members only help match members who left; votes still resolve without them
+		a.logger().WarnContext(ctx, "error looking up previous session Assembly members", "session", session-2, "error", err)
+		return verboseapi.NewMemberResolver(members), nil
+	}
+	return verboseapi.NewMemberResolver(members, previous), nil
+}
+
+// logger returns the Logger of the underlying verboseapi.NYSenateAPI or slog.Default()
+func (a *API) logger() *slog.Logger {
+	if a.api == nil || a.api.Logger == nil {
+		return slog.Default()
+	}
+	return a.api.Logger
 }
 
 type Envelope struct {
@@ -2,11 +2,15 @@ package nysenateapi
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/jehiah/nysenateapi/verboseapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func TestGetBill(t *testing.T) {
@@ -21,3 +25,23 @@ func TestGetBill(t *testing.T) {
 	require.NoError(t, err)
 	t.Logf("%#v", b)
 }
+
+func TestGetBillNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"message":"The requested bill was not found","responseType":"bill-not-found"}`))
+	}))
+	defer ts.Close()
+
+	v := verboseapi.NewAPI("token")
+	v.BaseURL = ts.URL
+	v.Limiter = rate.NewLimiter(rate.Inf, 1)
+	a := NewWithVerboseAPI(v)
+
+	b, err := a.GetBill(context.Background(), "2023", "S99999")
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	_, err = v.Bills(context.Background(), "2023", 0)
+	assert.ErrorIs(t, err, verboseapi.ErrNotFound)
+}
@@ -0,0 +1,114 @@
+package nysenateapi
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+// Video is a link to floor video
+type Video struct {
+	Title string `json:"Title,omitempty"`
+	URL   string `json:"URL"`
+}
+
+// i.e. "A09275A", "A9275"
+var paddedPrintNoPattern = regexp.MustCompile(`^([AS])0*(\d+)[A-Z]?$`)
+
+// basePrintNo returns a print number without zero padding or an amendment version i.e. "A09275A" is "A9275"
+func basePrintNo(printNo string) string {
+	printNo = strings.ToUpper(strings.TrimSpace(printNo))
+	if m := paddedPrintNoPattern.FindStringSubmatch(printNo); m != nil {
+		return m[1] + m[2]
+	}
+	return printNo
+}
+
+// EnrichWithAssembly adds actions, the sponsor memo, committee history and floor video links from
+// nyassembly.gov to an Assembly bill. Votes are not added; GetBill already includes Assembly votes
+// for Assembly bills. Senate bills are not changed; the page for an Assembly same-as bill describes
+// a different bill.
+func (a *API) EnrichWithAssembly(ctx context.Context, b *Bill) error {
+	if b.Chamber != "ASSEMBLY" {
+		return nil
+	}
+	// votes aren't used so members aren't needed to resolve them
+	ab, err := a.api.AssemblyBill(ctx, nil, fmt.Sprintf("%d", b.Session), b.PrintNo)
+	if err != nil {
+		return err
+	}
+	b.MergeAssembly(ab)
+	return nil
+}
+
+// MergeAssembly merges nyassembly.gov bill details into b. Actions, past committees and video links not
+// already present are added and the memo is set if missing. Nothing is merged when ab is a different bill
+// (i.e. the Assembly same-as of a Senate bill).
+func (b *Bill) MergeAssembly(ab *verboseapi.AssemblyBill) {
+	if ab == nil || basePrintNo(ab.PrintNo) != basePrintNo(b.PrintNo) {
+		return
+	}
+	actionKey := func(d civil.Date, text string) string {
+		return d.String() + " " + strings.Join(strings.Fields(strings.ToUpper(text)), " ")
+	}
+	seen := make(map[string]bool)
+	for _, a := range b.Actions {
+		seen[actionKey(a.Date, a.Text)] = true
+	}
+	added := false
+	for _, a := range ab.Actions {
+		action := Action{
+			Text:    a.Text,
+			Date:    civil.DateOf(parseTime(a.Date)),
+			Chamber: a.Chamber,
+		}
+		key := actionKey(action.Date, action.Text)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		b.Actions = append(b.Actions, action)
+		added = true
+	}
+	if added {
+		sort.SliceStable(b.Actions, func(i, j int) bool { return b.Actions[i].Date.Before(b.Actions[j].Date) })
+	}
+
+	seen = make(map[string]bool)
+	for _, c := range b.PastCommittees {
+		seen[actionKey(c.Date, c.Chamber+" "+c.Name)] = true
+	}
+	for _, c := range ab.Committees {
+		pc := PastCommittee{
+			Chamber: c.Chamber,
+			Name:    c.Name,
+			Date:    civil.DateOf(parseTime(c.ReferenceDate)),
+		}
+		key := actionKey(pc.Date, pc.Chamber+" "+pc.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		b.PastCommittees = append(b.PastCommittees, pc)
+	}
+
+	if b.Memo == nil {
+		b.Memo = ParseMemo(ab.Memo)
+	}
+	videos := make(map[string]bool)
+	for _, v := range b.Videos {
+		videos[v.URL] = true
+	}
+	for _, v := range ab.Videos {
+		if videos[v.URL] {
+			continue
+		}
+		videos[v.URL] = true
+		b.Videos = append(b.Videos, Video{Title: v.Title, URL: v.URL})
+	}
+}
@@ -0,0 +1,73 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeAssembly(t *testing.T) {
+	b := &Bill{
+		PrintNo: "A9275",
+		Chamber: "ASSEMBLY",
+		Actions: []Action{
+			{Date: date(2022, 3, 1), Text: "REFERRED TO ENVIRONMENTAL CONSERVATION", Chamber: "ASSEMBLY"},
+			{Date: date(2022, 6, 2), Text: "PASSED ASSEMBLY", Chamber: "ASSEMBLY"},
+		},
+		PastCommittees: []PastCommittee{
+			{Chamber: "ASSEMBLY", Name: "ENVIRONMENTAL CONSERVATION", Date: date(2022, 3, 1)},
+		},
+	}
+	ab := &verboseapi.AssemblyBill{
+		PrintNo: "A09275A",
+		Actions: []verboseapi.BillAction{
+			{Date: "2022-03-01", Text: "referred to environmental conservation", Chamber: "ASSEMBLY"},
+			{Date: "2022-05-17", Text: "reported referred to ways and means", Chamber: "ASSEMBLY"},
+			{Date: "2022-06-02", Text: "passed  assembly", Chamber: "ASSEMBLY"},
+		},
+		Committees: []verboseapi.PastCommittee{
+			{Chamber: "ASSEMBLY", Name: "ENVIRONMENTAL CONSERVATION", ReferenceDate: "2022-03-01"},
+			{Chamber: "SENATE", Name: "RULES", ReferenceDate: "2022-06-02"},
+		},
+		Memo:   "BILL NUMBER: A9275\n\nPURPOSE: Establishes an inventory.",
+		Videos: []verboseapi.AssemblyVideo{{Title: "Floor Video", URL: "https://nyassembly.gov/av/video/"}},
+	}
+	b.MergeAssembly(ab)
+
+	require.Len(t, b.Actions, 3)
+	assert.Equal(t, "reported referred to ways and means", b.Actions[1].Text)
+	assert.Equal(t, date(2022, 5, 17), b.Actions[1].Date)
+	require.Len(t, b.PastCommittees, 2)
+	assert.Equal(t, "RULES", b.PastCommittees[1].Name)
+	require.NotNil(t, b.Memo)
+	assert.Equal(t, "Establishes an inventory.", b.Memo.Purpose)
+	assert.Equal(t, []Video{{Title: "Floor Video", URL: "https://nyassembly.gov/av/video/"}}, b.Videos)
+
+	// merging again doesn't duplicate anything
+	b.MergeAssembly(ab)
+	assert.Len(t, b.Actions, 3)
+	assert.Len(t, b.PastCommittees, 2)
+	assert.Len(t, b.Videos, 1)
+
+	// an existing memo is kept
+	memo := &SponsorMemo{Purpose: "existing"}
+	b = &Bill{PrintNo: "A9275", Memo: memo}
+	b.MergeAssembly(ab)
+	assert.Same(t, memo, b.Memo)
+
+	// the Assembly same-as bill isn't merged into a Senate bill
+	b = &Bill{PrintNo: "S7710", Chamber: "SENATE", SameAsPrintNo: "A9275"}
+	b.MergeAssembly(ab)
+	assert.Empty(t, b.Actions)
+	assert.Empty(t, b.PastCommittees)
+	assert.Nil(t, b.Memo)
+	assert.Empty(t, b.Videos)
+}
+
+func TestBasePrintNo(t *testing.T) {
+	assert.Equal(t, "A9275", basePrintNo("A09275A"))
+	assert.Equal(t, "A9275", basePrintNo("a9275"))
+	assert.Equal(t, "S7710", basePrintNo("S07710"))
+}
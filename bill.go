@@ -23,26 +23,41 @@ type Bill struct {
 	Resolution bool   `json:"Resolution,omitempty"`
 
 	Published  time.Time   `json:"Published,omitempty"`
-	Status     string      `json:"Status,omitempty"`
+	Status     BillStatus  `json:"Status,omitempty"`
 	Committee  string      `json:"Committee,omitempty"`
 	Milestones []Milestone `json:"Milestones,omitempty"`
 	Actions    []Action    `json:"Actions,omitempty"`
 	Votes      []Vote      `json:"Votes,omitempty"`
-	Sponsors   []Sponsor   `json:"Sponsors,omitempty"`
+	// VoteConflicts are member votes where the OpenLegislation and nyassembly.gov records disagree
+	VoteConflicts []VoteConflict `json:"VoteConflicts,omitempty"`
+	Sponsors      []Sponsor      `json:"Sponsors,omitempty"`
 
 	Title      string `json:"Title,omitempty"`
 	Summary    string `json:"Summary,omitempty"`
 	LawSection string `json:"LawSection,omitempty"`
 	LawCode    string `json:"LawCode,omitempty"`
 	ActClause  string `json:"ActClause,omitempty"`
-	// TODO: BodyURL
+	BodyURL    string `json:"BodyURL,omitempty"`
+	PDFURL     string `json:"PDFURL,omitempty"`
+
+	Memo *SponsorMemo `json:"Memo,omitempty"`
+
+	PastCommittees []PastCommittee `json:"PastCommittees,omitempty"`
+	// Videos are floor video links from nyassembly.gov (see EnrichWithAssembly)
+	Videos []Video `json:"Videos,omitempty"`
 
 	SameAsPrintNo    string   `json:"SameAsPrintNo,omitempty"`
+	SubstitutedBy    string   `json:"SubstitutedBy,omitempty"`
 	PreviousVersions []string `json:"PreviousVersions,omitempty"`
+
+	Signed   bool      `json:"Signed,omitempty"`
+	Chapter  int       `json:"Chapter,omitempty"`
+	Approval *Approval `json:"Approval,omitempty"`
+	Vetoes   []Veto    `json:"Vetoes,omitempty"`
 }
 
 type Milestone struct {
-	Type      string
+	Type      BillStatus
 	Date      civil.Date
 	Committee string `json:"Committee,omitempty"`
 }
@@ -53,18 +68,43 @@ type Sponsor struct {
 	Short string
 }
 type Vote struct {
-	VoteType  string // COMMITTEE, FLOOR
-	Date      civil.Date
-	Version   string `json:"Version,omitempty"`
+	VoteType VoteKind // COMMITTEE, FLOOR
+	Date     civil.Date
+	Version  string `json:"Version,omitempty"`
+	// Active is set when the vote was on the active amendment
+	Active    bool   `json:"Active,omitempty"`
 	Chamber   string `json:"Chamber,omitempty"`
 	Committee string `json:"Committee,omitempty"`
-	Votes     []VoteEntry
+	Chair     string `json:"Chair,omitempty"`
+	// Outcome is the committee action on Assembly votes i.e. "Held for Consideration"
+	Outcome string `json:"Outcome,omitempty"`
+	Source  string `json:"Source,omitempty"` // SourceOpenLegislation, SourceAssembly
+	Votes   []VoteEntry
+	// Warnings are nyassembly.gov member names that were not matched to a member with full confidence
+	Warnings []MemberWarning `json:"Warnings,omitempty"`
+}
+
+type MemberWarning struct {
+	Name       string    `json:"Name"`
+	Vote       VoteValue `json:"Vote"`
+	Reason     string    `json:"Reason"` // verboseapi.MemberUnresolved, MemberAmbiguous, MemberLowConfidence
+	Confidence float64   `json:"Confidence"`
+	ID         int       `json:"ID,omitempty"`
+	Candidates []Sponsor `json:"Candidates,omitempty"`
 }
 type VoteEntry struct {
 	ID    int
-	Name  string `json:"Name,omitempty"`
-	Short string `json:"Short,omitempty"`
-	Vote  string // Aye, Nay, Excused
+	Name  string    `json:"Name,omitempty"`
+	Short string    `json:"Short,omitempty"`
+	Vote  VoteValue // Aye, Nay, Excused, Absent, Abstained
+	// Qualifier is set for votes "with reservations"
+	Qualifier string `json:"Qualifier,omitempty"`
+}
+
+type PastCommittee struct {
+	Chamber string     `json:"Chamber,omitempty"`
+	Name    string     `json:"Name"`
+	Date    civil.Date `json:"Date"`
 }
 
 type Action struct {
@@ -85,17 +125,20 @@ func newBill(b *verboseapi.Bill) *Bill {
 		BillType:   b.BillType.Desc,
 		Resolution: b.BillType.Resolution,
 		Published:  parseTime(b.PublishedDateTime),
-		Status:     b.Status.StatusType,
+		Status:     ParseBillStatus(b.Status.StatusType),
 		Committee:  b.Status.CommitteeName,
 		Title:      b.Title,
 		Summary:    b.Summary,
 		LawSection: b.Amendments.Items[b.ActiveVersion].LawSection,
 		LawCode:    b.Amendments.Items[b.ActiveVersion].LawCode,
 		ActClause:  newlineReplacer.Replace(b.Amendments.Items[b.ActiveVersion].ActClause),
+		BodyURL:    BodyURL(b.Session, b.BasePrintNo, b.ActiveVersion),
+		PDFURL:     PDFURL(b.Session, b.BasePrintNo, b.ActiveVersion),
+		Memo:       ParseMemo(b.Amendments.Items[b.ActiveVersion].Memo),
 	}
 	for _, m := range b.Milestones.Items {
 		bill.Milestones = append(bill.Milestones, Milestone{
-			Type:      m.StatusType,
+			Type:      ParseBillStatus(m.StatusType),
 			Date:      civil.DateOf(parseTime(m.ActionDate)),
 			Committee: m.CommitteeName,
 		})
@@ -108,6 +151,13 @@ func newBill(b *verboseapi.Bill) *Bill {
 			Version: m.BillID.Version,
 		})
 	}
+	for _, c := range b.PastCommittees.Items {
+		bill.PastCommittees = append(bill.PastCommittees, PastCommittee{
+			Chamber: c.Chamber,
+			Name:    c.Name,
+			Date:    civil.DateOf(parseTime(c.ReferenceDate)),
+		})
+	}
 	// sponsors (including multi-sponsors, etc)
 	seen := map[int]bool{}
 	if b.Sponsor.Member.MemberID > 0 {
@@ -143,7 +193,20 @@ func newBill(b *verboseapi.Bill) *Bill {
 	if b.Amendments.Items[b.ActiveVersion].SameAs.Size > 0 {
 		bill.SameAsPrintNo = b.Amendments.Items[b.ActiveVersion].SameAs.Items[0].BasePrintNoStr
 	}
-	bill.Votes = newVotes(b.Votes.Items)
+	bill.SubstitutedBy = b.SubstitutedBy.BasePrintNo
+	bill.Votes = newVotes(b.Votes.Items, SourceOpenLegislation, b.ActiveVersion)
+	// governor actions
+	bill.Signed = b.Signed
+	bill.Approval = newApproval(b.ApprovalMessage)
+	for _, v := range b.VetoMessages.Items {
+		bill.Vetoes = append(bill.Vetoes, newVeto(v))
+	}
+	switch {
+	case bill.Approval != nil && bill.Approval.Chapter > 0:
+		bill.Chapter = bill.Approval.Chapter
+	case b.Signed:
+		bill.Chapter = chapterFromActions(bill.Actions)
+	}
 	// previousVersions
 	sort.Slice(b.PreviousVersions.Items, func(i, j int) bool { return b.PreviousVersions.Items[i].Session < b.PreviousVersions.Items[j].Session })
 	for _, v := range b.PreviousVersions.Items {
@@ -153,66 +216,66 @@ func newBill(b *verboseapi.Bill) *Bill {
 	return bill
 }
 
-func newVotes(bv []verboseapi.BillVote) []Vote {
+// newVotes converts votes from source; activeVersion is the bill's active amendment
+func newVotes(bv []verboseapi.BillVote, source, activeVersion string) []Vote {
 	var o []Vote
 	for _, v := range bv {
 		o = append(o, Vote{
-			VoteType:  v.VoteType,
-			Date:      civil.DateOf(parseTime(v.VoteDate)),
-			Version:   v.Version,
+			VoteType: ParseVoteKind(v.VoteType),
+			Date:     civil.DateOf(parseTime(v.VoteDate)),
+			Version:  v.Version,
+			// nyassembly.gov votes don't have a version
+			Active:    v.Version == activeVersion || (v.Version == "" && source == SourceAssembly),
 			Chamber:   v.Committee.Chamber,
 			Committee: v.Committee.Name,
+			Chair:     v.Committee.Chair,
+			Outcome:   v.Outcome,
+			Source:    source,
 			Votes:     newVoteEntries(v.MemberVotes.Items),
+			Warnings:  newMemberWarnings(v.Warnings),
 		})
 	}
 	return o
 }
 
+func newMemberWarnings(w []verboseapi.MemberWarning) []MemberWarning {
+	var o []MemberWarning
+	for _, ww := range w {
+		mw := MemberWarning{
+			Name:       ww.Name,
+			Vote:       ParseVoteValue(ww.Vote),
+			Reason:     ww.Reason,
+			Confidence: ww.Confidence,
+			ID:         ww.MemberID,
+		}
+		for _, c := range ww.Candidates {
+			mw.Candidates = append(mw.Candidates, Sponsor{ID: c.MemberID, Name: c.FullName, Short: c.ShortName})
+		}
+		o = append(o, mw)
+	}
+	return o
+}
+
 func newVoteEntries(v verboseapi.MemberVotes) []VoteEntry {
 	var o []VoteEntry
-	// TODO: dedupe
-	for _, m := range v.Aye.Items {
-		o = append(o, VoteEntry{
-			ID:    m.MemberID,
-			Short: m.ShortName,
-			Name:  m.FullName,
-			Vote:  "Aye",
-		})
-	}
-	for _, m := range v.AyeWithReservations.Items {
-		o = append(o, VoteEntry{
-			ID:    m.MemberID,
-			Short: m.ShortName,
-			Name:  m.FullName,
-			Vote:  "Aye",
-			// TODO: add note "with reservations"
-		})
-	}
-	for _, m := range v.Nay.Items {
-		o = append(o, VoteEntry{
-			ID:    m.MemberID,
-			Short: m.ShortName,
-			Name:  m.FullName,
-			Vote:  "Nay",
-		})
-	}
-	for _, m := range v.Excused.Items {
-		o = append(o, VoteEntry{
-			ID:    m.MemberID,
-			Short: m.ShortName,
-			Name:  m.FullName,
-			Vote:  "Excused",
-		})
-	}
-	for _, m := range v.Absent.Items {
-		o = append(o, VoteEntry{
-			ID:    m.MemberID,
-			Short: m.ShortName,
-			Name:  m.FullName,
-			Vote:  "Absent",
-		})
+	seen := make(map[string]bool)
+	for _, c := range v.Codes() {
+		vote, qualifier := verboseapi.VoteCodeDescription(c.Code)
+		for _, m := range c.Members {
+			key := memberKey(m.MemberID, m.ShortName)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			o = append(o, VoteEntry{
+				ID:        m.MemberID,
+				Short:     m.ShortName,
+				Name:      m.FullName,
+				Vote:      ParseVoteValue(vote),
+				Qualifier: qualifier,
+			})
+		}
 	}
-	// TODO: Abstained ?
 	return o
 }
 
@@ -230,3 +293,14 @@ func parseTime(s string) time.Time {
 	}
 	return time.Unix(0, 0)
 }
+
+// ActiveVotes returns the votes on the active amendment
+func (b Bill) ActiveVotes() []Vote {
+	var o []Vote
+	for _, v := range b.Votes {
+		if v.Active {
+			o = append(o, v)
+		}
+	}
+	return o
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
 )
 
 func Test_parseTime(t *testing.T) {
@@ -34,3 +36,52 @@ func Test_parseTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_newVoteEntries(t *testing.T) {
+	var mv verboseapi.MemberVotes
+	mv.AyeWithReservations.Items = []verboseapi.MemberEntry{{MemberID: 1, ShortName: "A"}}
+	mv.Abstained.Items = []verboseapi.MemberEntry{{MemberID: 2, ShortName: "B"}}
+	mv.Other = map[string]verboseapi.MemberEntryList{"XYZ": {Items: []verboseapi.MemberEntry{{MemberID: 3, ShortName: "C"}}}}
+	got := newVoteEntries(mv)
+	want := []VoteEntry{
+		{ID: 1, Short: "A", Vote: VoteAye, Qualifier: "with reservations"},
+		{ID: 2, Short: "B", Vote: VoteAbstained},
+		{ID: 3, Short: "C", Vote: VoteValue("XYZ")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newVoteEntries() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_newBillVoteVersions(t *testing.T) {
+	b := &verboseapi.Bill{BasePrintNo: "S1234", Session: 2023, ActiveVersion: "A"}
+	b.Votes.Items = []verboseapi.BillVote{
+		{Version: "", VoteType: "COMMITTEE", VoteDate: "2023-03-01"},
+		{Version: "A", VoteType: "FLOOR", VoteDate: "2023-06-01"},
+	}
+	bill := newBill(b)
+	if len(bill.Votes) != 2 {
+		t.Fatalf("expected 2 votes got %d", len(bill.Votes))
+	}
+	if bill.Votes[0].Active || !bill.Votes[1].Active {
+		t.Errorf("unexpected Active %#v", bill.Votes)
+	}
+	if active := bill.ActiveVotes(); len(active) != 1 || active[0].Version != "A" {
+		t.Errorf("ActiveVotes() = %#v", active)
+	}
+}
+
+func Test_newVotesAssembly(t *testing.T) {
+	bv := verboseapi.BillVote{VoteType: "COMMITTEE", VoteDate: "2024-05-14", Outcome: "Held for Consideration"}
+	bv.Committee.Chamber = "ASSEMBLY"
+	bv.Committee.Name = "Codes"
+	bv.Committee.Chair = "Lavine"
+	votes := newVotes([]verboseapi.BillVote{bv}, SourceAssembly, "")
+	if len(votes) != 1 {
+		t.Fatalf("expected 1 vote got %d", len(votes))
+	}
+	v := votes[0]
+	if v.VoteType != CommitteeVote || v.Outcome != "Held for Consideration" || v.Chair != "Lavine" || v.Committee != "Codes" {
+		t.Errorf("unexpected vote %#v", v)
+	}
+}
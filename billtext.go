@@ -0,0 +1,227 @@
+package nysenateapi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+// BillText is the text of a bill amendment split into pages and lines matching the official print
+// so that a reference like "page 3, line 12" can be resolved.
+type BillText struct {
+	PrintNo string     `json:"PrintNo"`
+	Version string     `json:"Version,omitempty"`
+	Session int        `json:"Session"`
+	Pages   []TextPage `json:"Pages,omitempty"`
+}
+
+type TextPage struct {
+	Number int        `json:"Number"`
+	Lines  []TextLine `json:"Lines,omitempty"`
+}
+
+// TextLine is a single line of bill text. Lines outside the numbered body of the bill
+// (i.e. the title page header, page headers, explanation footer) have Number 0.
+type TextLine struct {
+	Number int    `json:"Number,omitempty"`
+	Text   string `json:"Text"`
+}
+
+// Line returns the text of a numbered line on a page
+func (b BillText) Line(page, line int) (string, bool) {
+	for _, p := range b.Pages {
+		if p.Number != page {
+			continue
+		}
+		for _, l := range p.Lines {
+			if l.Number == line {
+				return l.Text, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Lines returns the numbered lines from..to (inclusive) on a page
+func (b BillText) Lines(page, from, to int) []string {
+	var o []string
+	for _, p := range b.Pages {
+		if p.Number != page {
+			continue
+		}
+		for _, l := range p.Lines {
+			if l.Number >= from && l.Number <= to && l.Number != 0 {
+				o = append(o, l.Text)
+			}
+		}
+	}
+	return o
+}
+
+var (
+	// i.e. "        S. 2304--A                          2"
+	pageHeaderPattern = regexp.MustCompile(`^\s*[A-Z]\. \d+(?:--[A-Z])?\s+(\d+)\s*$`)
+	// line numbers are right aligned in the left margin
+	lineNumberPattern = regexp.MustCompile(`^ {0,6}(\d{1,2})(?:\s+(.*))?$`)
+)
+
+// ParseBillText splits the plain text format of a bill into pages and numbered lines
+func ParseBillText(s string) []TextPage {
+	page := TextPage{Number: 1}
+	var pages []TextPage
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r\f")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if m := pageHeaderPattern.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			if n > page.Number {
+				pages = append(pages, page)
+				page = TextPage{Number: n}
+			}
+			page.Lines = append(page.Lines, TextLine{Text: strings.TrimSpace(line)})
+			continue
+		}
+		if m := lineNumberPattern.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			page.Lines = append(page.Lines, TextLine{Number: n, Text: strings.TrimSpace(m[2])})
+			continue
+		}
+		page.Lines = append(page.Lines, TextLine{Text: strings.TrimSpace(line)})
+	}
+	return append(pages, page)
+}
+
+// GetRawBillText returns the unparsed full text of an amendment version of a bill ("" is the original print)
+func (a *API) GetRawBillText(ctx context.Context, session, printNo, version string, format verboseapi.FullTextFormat) (string, error) {
+	bill, err := a.api.GetBillText(ctx, session, printNo, format)
+	if err != nil {
+		return "", err
+	}
+	if bill == nil {
+		return "", nil
+	}
+	amendment, ok := bill.Amendments.Items[version]
+	if !ok {
+		return "", fmt.Errorf("version %q of %s-%s not found", version, printNo, session)
+	}
+	switch format {
+	case verboseapi.HTMLText:
+		return amendment.FullTextHTML, nil
+	case verboseapi.TemplateText:
+		return amendment.FullTextTemplate, nil
+	}
+	return amendment.FullText, nil
+}
+
+// GetBillText returns the parsed text of an amendment version of a bill ("" is the original print)
+func (a *API) GetBillText(ctx context.Context, session, printNo, version string) (*BillText, error) {
+	bill, err := a.api.GetBillText(ctx, session, printNo, verboseapi.PlainText)
+	if err != nil {
+		return nil, err
+	}
+	if bill == nil {
+		return nil, nil
+	}
+	amendment, ok := bill.Amendments.Items[version]
+	if !ok {
+		return nil, fmt.Errorf("version %q of %s-%s not found", version, printNo, session)
+	}
+	return &BillText{
+		PrintNo: bill.BasePrintNo,
+		Version: version,
+		Session: bill.Session,
+		Pages:   ParseBillText(amendment.FullText),
+	}, nil
+}
+
+// BodyURL returns the nysenate.gov page for a bill amendment
+func BodyURL(session int, printNo, version string) string {
+	u := fmt.Sprintf("https://www.nysenate.gov/legislation/bills/%d/%s", session, printNo)
+	if version != "" {
+		u += "/amendment/" + version
+	}
+	return u
+}
+
+// PDFURL returns the official print of a bill amendment
+func PDFURL(session int, printNo, version string) string {
+	return fmt.Sprintf("https://legislation.nysenate.gov/pdf/bills/%d/%s%s", session, printNo, version)
+}
+
+// compoundPrefixes are words that form hyphenated compounds ("self-insured", "twenty-five") and keep
+// their hyphen when the compound is broken across lines
+var compoundPrefixes = map[string]bool{
+	"self": true, "ex": true, "all": true, "quasi": true, "half": true, "cross": true, "well": true,
+	"twenty": true, "thirty": true, "forty": true, "fifty": true, "sixty": true, "seventy": true, "eighty": true, "ninety": true,
+}
+
+var hyphenatedWordPattern = regexp.MustCompile(`[A-Za-z0-9]+(?:-[A-Za-z0-9]+)+`)
+
+// hyphenation rejoins words broken at a line end ("pedes-" "trian"). Hyphenated compounds that appear
+// elsewhere in the text are kept.
+type hyphenation map[string]bool
+
+func newHyphenation(text string) hyphenation {
+	h := make(hyphenation)
+	for _, w := range hyphenatedWordPattern.FindAllString(text, -1) {
+		h[strings.ToLower(w)] = true
+	}
+	return h
+}
+
+// breaks reports if head (ending a line) and tail (starting the next) are one word broken by a hyphen
+func (h hyphenation) breaks(head, tail string) bool {
+	return len(head) > 1 && strings.HasSuffix(head, "-") && tail != "" && tail[0] >= 'a' && tail[0] <= 'z'
+}
+
+// join returns head and tail as one word dropping the line break hyphen unless it's part of a compound
+func (h hyphenation) join(head, tail string) string {
+	if h.compound(head, tail) {
+		return head + tail
+	}
+	return strings.TrimSuffix(head, "-") + tail
+}
+
+func (h hyphenation) compound(head, tail string) bool {
+	stem := strings.ToLower(strings.TrimSuffix(head, "-"))
+	if i := strings.LastIndexAny(stem, "-([\"'"); i != -1 {
+		// already a compound i.e. "one-hundred-" or a leading paren
+		if stem[i] == '-' {
+			return true
+		}
+		stem = stem[i+1:]
+	}
+	if compoundPrefixes[stem] || strings.ContainsAny(stem, "0123456789") {
+		return true
+	}
+	end := strings.IndexFunc(tail, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if end == -1 {
+		end = len(tail)
+	}
+	return h[stem+"-"+strings.ToLower(tail[:end])]
+}
+
+// joinText joins runs of text with a space except before closing punctuation or after an opening paren
+func joinText(parts []string) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if b.Len() > 0 && !strings.ContainsAny(p[:1], ".,;:)]") && !strings.HasSuffix(b.String(), "(") {
+			b.WriteString(" ")
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBillText = `
+                           S T A T E   O F   N E W   Y O R K
+       ________________________________________________________________________
+
+                                          2304--A
+
+                              2023-2024 Regular Sessions
+
+                                   I N  S E N A T E
+
+                                    January 19, 2023
+                                       ___________
+
+        Introduced  by  Sen. GOUNARDES -- read twice and ordered printed, and when
+          printed to be committed to the Committee on Cities 1
+
+        AN ACT to amend the administrative code of the city of New York, in
+          relation to bicycle parking
+
+          The  People of the State of New York, represented in Senate and Assem-
+        bly, do enact as follows:
+
+     1    Section 1. Section 19-190 of the administrative code of the city of
+     2  New York is amended to read as follows:
+     3    § 19-190 Right of way. a. Except as provided in subdivision b of this
+
+        EXPLANATION--Matter in ITALICS (underscored) is new; matter in brackets
+                             [ ] is old law to be omitted.
+                                                                  LBD05678-02-3
+
+        S. 2304--A                          2
+
+     1  section, any driver of a motor vehicle who fails to yield to a pedes-
+     2  trian or person riding a bicycle
+    12    § 2. This act shall take effect immediately.
+`
+
+func TestParseBillText(t *testing.T) {
+	b := BillText{Pages: ParseBillText(sampleBillText)}
+	require.Len(t, b.Pages, 2)
+	assert.Equal(t, 1, b.Pages[0].Number)
+	assert.Equal(t, 2, b.Pages[1].Number)
+
+	l, ok := b.Line(1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "New York is amended to read as follows:", l)
+
+	l, ok = b.Line(2, 12)
+	assert.True(t, ok)
+	assert.Equal(t, "§ 2. This act shall take effect immediately.", l)
+
+	_, ok = b.Line(2, 3)
+	assert.False(t, ok)
+	_, ok = b.Line(3, 1)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{
+		"Section 1. Section 19-190 of the administrative code of the city of",
+		"New York is amended to read as follows:",
+	}, b.Lines(1, 1, 2))
+	assert.Equal(t, "S. 2304--A                          2", b.Pages[1].Lines[0].Text)
+}
+
+func TestBillURLs(t *testing.T) {
+	assert.Equal(t, "https://www.nysenate.gov/legislation/bills/2023/S2304", BodyURL(2023, "S2304", ""))
+	assert.Equal(t, "https://www.nysenate.gov/legislation/bills/2023/S2304/amendment/A", BodyURL(2023, "S2304", "A"))
+	assert.Equal(t, "https://legislation.nysenate.gov/pdf/bills/2023/S2304A", PDFURL(2023, "S2304", "A"))
+}
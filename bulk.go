@@ -0,0 +1,110 @@
+package nysenateapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// DefaultBillWorkers is the number of concurrent GetBill requests when GetBillsOptions.Workers is not set
+const DefaultBillWorkers = 4
+
+type GetBillsOptions struct {
+	// Workers is the maximum number of bills fetched concurrently (default DefaultBillWorkers).
+	// Requests still share the API rate limiter.
+	Workers int
+	// Progress is called after each bill is fetched (or fails) with the number of bills completed
+	// and the total. Calls are serialized.
+	Progress func(done, total int)
+}
+
+// BillResult is the result of fetching a single bill. Bill is nil when the bill was not found or Err is set.
+type BillResult struct {
+	Ref  BillReference
+	Bill *Bill
+	Err  error
+}
+
+// GetBills fetches bills concurrently and sends results on the returned channel in the order they complete.
+// An error for one bill does not stop the others. The channel is closed after all bills are fetched or ctx
+// is cancelled; callers must read from the channel until it is closed.
+func (a *API) GetBills(ctx context.Context, refs []BillReference, opts GetBillsOptions) <-chan BillResult {
+	return getBills(ctx, refs, opts, func(ctx context.Context, ref BillReference) (*Bill, error) {
+		return a.GetBill(ctx, strconv.Itoa(ref.Session), ref.PrintNo)
+	})
+}
+
+func getBills(ctx context.Context, refs []BillReference, opts GetBillsOptions, fetch func(context.Context, BillReference) (*Bill, error)) <-chan BillResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBillWorkers
+	}
+	if workers > len(refs) {
+		workers = len(refs)
+	}
+	out := make(chan BillResult)
+	work := make(chan BillReference)
+
+	var progressMutex sync.Mutex
+	var done int
+	progress := func() {
+		if opts.Progress == nil {
+			return
+		}
+		progressMutex.Lock()
+		defer progressMutex.Unlock()
+		done++
+		opts.Progress(done, len(refs))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range work {
+				bill, err := fetch(ctx, ref)
+				progress()
+				select {
+				case out <- BillResult{Ref: ref, Bill: bill, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(work)
+		for _, ref := range refs {
+			select {
+			case work <- ref:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// CollectBills reads all results and returns the bills that were found along with an error
+// joining any per-bill errors.
+func CollectBills(results <-chan BillResult) ([]*Bill, error) {
+	var bills []*Bill
+	var errs []error
+	for r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s-%d: %w", r.Ref.PrintNo, r.Ref.Session, r.Err))
+			continue
+		}
+		if r.Bill != nil {
+			bills = append(bills, r.Bill)
+		}
+	}
+	return bills, errors.Join(errs...)
+}
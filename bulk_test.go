@@ -0,0 +1,89 @@
+package nysenateapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRefs(n int) []BillReference {
+	var refs []BillReference
+	for i := 1; i <= n; i++ {
+		refs = append(refs, BillReference{PrintNo: fmt.Sprintf("S%d", i), Session: 2023})
+	}
+	return refs
+}
+
+func TestGetBills(t *testing.T) {
+	var inFlight, maxInFlight int32
+	fetch := func(ctx context.Context, ref BillReference) (*Bill, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		switch ref.PrintNo {
+		case "S3":
+			return nil, errors.New("boom")
+		case "S5":
+			return nil, nil
+		}
+		return &Bill{PrintNo: ref.PrintNo, Session: ref.Session}, nil
+	}
+
+	var progress []int
+	var mu sync.Mutex
+	opts := GetBillsOptions{
+		Workers: 3,
+		Progress: func(done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 20, total)
+			progress = append(progress, done)
+		},
+	}
+	bills, err := CollectBills(getBills(context.Background(), testRefs(20), opts, fetch))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "S3-2023: boom")
+	assert.Len(t, bills, 18)
+	assert.LessOrEqual(t, maxInFlight, int32(3))
+	assert.Len(t, progress, 20)
+	for i, p := range progress {
+		assert.Equal(t, i+1, p)
+	}
+}
+
+func TestGetBillsEmpty(t *testing.T) {
+	bills, err := CollectBills(getBills(context.Background(), nil, GetBillsOptions{}, nil))
+	require.NoError(t, err)
+	assert.Empty(t, bills)
+}
+
+func TestGetBillsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	fetch := func(ctx context.Context, ref BillReference) (*Bill, error) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			cancel()
+		}
+		return &Bill{PrintNo: ref.PrintNo}, ctx.Err()
+	}
+	results := getBills(ctx, testRefs(100), GetBillsOptions{Workers: 1}, fetch)
+	n := 0
+	for range results {
+		n++
+	}
+	assert.Less(t, n, 100)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(100))
+}
@@ -0,0 +1,166 @@
+package nysenateapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"cloud.google.com/go/civil"
+)
+
+// ChapterLaw is a bill signed into law as a numbered chapter of the laws of a year
+type ChapterLaw struct {
+	Year       int        `json:"Year"`
+	Chapter    int        `json:"Chapter"`
+	SignedDate civil.Date `json:"SignedDate,omitempty"`
+	Bill       *Bill      `json:"Bill"`
+	// Amendments are later bills (chapter amendments) that reference this chapter
+	Amendments []BillReference `json:"Amendments,omitempty"`
+}
+
+// sessionYear returns the start of the two year legislative session containing year
+func sessionYear(year int) int {
+	if year%2 == 0 {
+		return year - 1
+	}
+	return year
+}
+
+// SignedDate returns the date a bill was signed by the governor
+func (b Bill) SignedDate() civil.Date {
+	for _, m := range b.Milestones {
+		if m.Type == StatusSignedByGovernor {
+			return m.Date
+		}
+	}
+	for i := len(b.Actions) - 1; i >= 0; i-- {
+		if ClassifyAction(b.Actions[i]).Type == ActionSignature {
+			return b.Actions[i].Date
+		}
+	}
+	return civil.Date{}
+}
+
+// GetChapter resolves "Chapter N of the Laws of YYYY" to the bill that was signed.
+//
+// A bill search on the chapter action is checked first, then the governor's approval memos.
+// It returns nil when the chapter can't be found.
+func (a *API) GetChapter(ctx context.Context, year, chapter int) (*ChapterLaw, error) {
+	if year == 0 || chapter == 0 {
+		return nil, nil
+	}
+	seen := make(map[BillReference]bool)
+	// match returns the first candidate signed as chapter in year
+	match := func(candidates []BillReference) (*Bill, error) {
+		for _, c := range candidates {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			b, err := a.api.GetBill(ctx, strconv.Itoa(c.Session), c.PrintNo)
+			if err != nil {
+				return nil, err
+			}
+			if b == nil {
+				continue
+			}
+			bill := newBill(b)
+			if bill.Chapter != chapter {
+				continue
+			}
+			if signed := bill.SignedDate(); signed.IsValid() && signed.Year != year {
+				continue
+			}
+			return bill, nil
+		}
+		return nil, nil
+	}
+
+	session := strconv.Itoa(sessionYear(year))
+	candidates, err := a.searchBills(ctx, session, fmt.Sprintf(`signed:true AND "CHAP.%d"`, chapter))
+	if err != nil {
+		return nil, err
+	}
+	bill, err := match(candidates)
+	if err != nil {
+		return nil, err
+	}
+	if bill == nil {
+		approvals, err := a.Approvals(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+		candidates = nil
+		for _, ap := range approvals {
+			if ap.Chapter == chapter && ap.Bill.PrintNo != "" {
+				candidates = append(candidates, ap.Bill)
+			}
+		}
+		if bill, err = match(candidates); err != nil || bill == nil {
+			return nil, err
+		}
+	}
+
+	bill, err = a.withVotes(ctx, bill)
+	if err != nil {
+		return nil, err
+	}
+	out := &ChapterLaw{
+		Year:       year,
+		Chapter:    chapter,
+		SignedDate: bill.SignedDate(),
+		Bill:       bill,
+	}
+	out.Amendments, err = a.chapterAmendments(ctx, year, chapter, BillReference{PrintNo: bill.PrintNo, Session: bill.Session})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// searchBills returns every bill matching a search term
+func (a *API) searchBills(ctx context.Context, session, term string) ([]BillReference, error) {
+	var out []BillReference
+	offset := 1
+	for {
+		resp, err := a.api.SearchBills(ctx, session, term, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return out, nil
+		}
+		for _, r := range resp.Result.Items {
+			out = append(out, BillReference{PrintNo: r.Result.BasePrintNo, Session: r.Result.Session})
+		}
+		if resp.OffsetEnd >= resp.Total || len(resp.Result.Items) == 0 {
+			return out, nil
+		}
+		offset = resp.OffsetEnd + 1
+	}
+}
+
+// chapterAmendments finds bills in the same or following session that amend a chapter
+func (a *API) chapterAmendments(ctx context.Context, year, chapter int, bill BillReference) ([]BillReference, error) {
+	var out []BillReference
+	term := fmt.Sprintf(`"chapter %d of the laws of %d"`, chapter, year)
+	sessions := []int{sessionYear(year)}
+	if s := sessionYear(year + 1); s != sessions[0] {
+		sessions = append(sessions, s)
+	}
+	seen := map[BillReference]bool{bill: true}
+	for _, session := range sessions {
+		refs, err := a.searchBills(ctx, strconv.Itoa(session), term)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			out = append(out, ref)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+package nysenateapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSessionYear(t *testing.T) {
+	assert.Equal(t, 2023, sessionYear(2023))
+	assert.Equal(t, 2023, sessionYear(2024))
+	assert.Equal(t, 2025, sessionYear(2025))
+}
+
+func TestSignedDate(t *testing.T) {
+	b := Bill{Actions: []Action{
+		{Text: "DELIVERED TO GOVERNOR", Date: civil.Date{Year: 2023, Month: 12, Day: 1}},
+		{Text: "SIGNED CHAP.45", Date: civil.Date{Year: 2023, Month: 12, Day: 8}},
+	}}
+	assert.Equal(t, civil.Date{Year: 2023, Month: 12, Day: 8}, b.SignedDate())
+
+	b.Milestones = []Milestone{{Type: "SIGNED_BY_GOV", Date: civil.Date{Year: 2023, Month: 12, Day: 9}}}
+	assert.Equal(t, civil.Date{Year: 2023, Month: 12, Day: 9}, b.SignedDate())
+
+	assert.False(t, Bill{}.SignedDate().IsValid())
+}
+
+func senateBillJSON(printNo string, chapter int) string {
+	return fmt.Sprintf(`{"success":true,"result":{"basePrintNo":%q,"session":2023,"printNo":%q,"billType":{"chamber":"SENATE"},"signed":true,
+		"actions":{"items":[{"date":"2023-12-08","chamber":"SENATE","text":"SIGNED CHAP.%d"}],"size":1}}}`, printNo, printNo, chapter)
+}
+
+func TestGetChapter(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path
+		if term := r.URL.Query().Get("term"); term != "" {
+			key += " " + term + " " + r.URL.Query().Get("offset")
+		}
+		mu.Lock()
+		requests[key]++
+		mu.Unlock()
+		switch key {
+		case `/api/3/bills/2023/search signed:true AND "CHAP.45" `:
+			w.Write([]byte(`{"success":true,"total":2,"offsetStart":1,"offsetEnd":1,"result":{"items":[{"result":{"basePrintNo":"S100","session":2023}}],"size":1}}`))
+		case `/api/3/bills/2023/search signed:true AND "CHAP.45" 2`:
+			w.Write([]byte(`{"success":true,"total":2,"offsetStart":2,"offsetEnd":2,"result":{"items":[{"result":{"basePrintNo":"S200","session":2023}}],"size":1}}`))
+		case `/api/3/bills/2023/search "chapter 45 of the laws of 2023" `:
+			w.Write([]byte(`{"success":true,"total":2,"offsetStart":1,"offsetEnd":2,"result":{"items":[{"result":{"basePrintNo":"S200","session":2023}},{"result":{"basePrintNo":"S300","session":2023}}],"size":2}}`))
+		case "/api/3/bills/2023/S100":
+			w.Write([]byte(senateBillJSON("S100", 44)))
+		case "/api/3/bills/2023/S200":
+			w.Write([]byte(senateBillJSON("S200", 45)))
+		default:
+			w.Write([]byte(`{"success":true,"total":0,"result":{"items":[],"size":0}}`))
+		}
+	}))
+	defer ts.Close()
+
+	v := verboseapi.NewAPI("token")
+	v.BaseURL = ts.URL
+	v.Limiter = rate.NewLimiter(rate.Inf, 1)
+	a := NewWithVerboseAPI(v)
+
+	c, err := a.GetChapter(context.Background(), 2023, 45)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, "S200", c.Bill.PrintNo)
+	assert.Equal(t, civil.Date{Year: 2023, Month: 12, Day: 8}, c.SignedDate)
+	assert.Equal(t, []BillReference{{PrintNo: "S300", Session: 2023}}, c.Amendments)
+	// the second page of search results is read and approvals aren't needed
+	assert.Equal(t, 1, requests[`/api/3/bills/2023/search signed:true AND "CHAP.45" 2`])
+	assert.Equal(t, 0, requests["/api/3/approvals/2023"])
+	assert.Equal(t, 1, requests["/api/3/bills/2023/S200"])
+
+	// falls back to approval memos
+	c, err = a.GetChapter(context.Background(), 2023, 46)
+	require.NoError(t, err)
+	assert.Nil(t, c)
+	assert.Equal(t, 1, requests["/api/3/approvals/2023"])
+}
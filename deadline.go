@@ -0,0 +1,127 @@
+package nysenateapi
+
+import (
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// SessionCalendar records the final adjournment (sine die) of the legislature for each calendar year.
+//
+// Years without an entry are treated as still in session, which matches the customary practice of
+// the legislature recessing rather than adjourning so that bills delivered through December fall
+// under the ten day rule.
+type SessionCalendar struct {
+	Adjournments map[int]civil.Date
+}
+
+type DeadlineRule string
+
+const (
+	// TenDayRule applies when the legislature is in session: 10 days (Sundays excepted) after delivery
+	TenDayRule DeadlineRule = "10 days (Sundays excepted)"
+	// ThirtyDayRule applies when the legislature adjourns before the 10 days expire: 30 days after adjournment,
+	// or after delivery for a bill delivered after adjournment
+	ThirtyDayRule DeadlineRule = "30 days after adjournment or delivery"
+)
+
+type GovernorOutcome string
+
+const (
+	GovernorPending     GovernorOutcome = "pending"
+	GovernorSigned      GovernorOutcome = "signed"
+	GovernorVetoed      GovernorOutcome = "vetoed"
+	LawWithoutSignature GovernorOutcome = "law without signature"
+	PocketVetoed        GovernorOutcome = "pocket veto"
+)
+
+// GovernorDeadline is the deadline for the governor to act on a bill under Article IV §7 of the NY Constitution
+type GovernorDeadline struct {
+	Delivered civil.Date      `json:"Delivered"`
+	Rule      DeadlineRule    `json:"Rule"`
+	Deadline  civil.Date      `json:"Deadline"`
+	Outcome   GovernorOutcome `json:"Outcome"`
+}
+
+// deliveredDate returns the date a bill was delivered to the governor
+func (b Bill) deliveredDate() civil.Date {
+	for _, m := range b.Milestones {
+		if m.Type == StatusDeliveredToGovernor {
+			return m.Date
+		}
+	}
+	for i := len(b.Actions) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToUpper(b.Actions[i].Text), "DELIVERED TO GOVERNOR") {
+			return b.Actions[i].Date
+		}
+	}
+	return civil.Date{}
+}
+
+func (b Bill) vetoed() bool {
+	if len(b.Vetoes) > 0 {
+		return true
+	}
+	for _, m := range b.Milestones {
+		if m.Type == StatusVetoed {
+			return true
+		}
+	}
+	for _, a := range b.Actions {
+		if strings.HasPrefix(strings.ToUpper(a.Text), "VETOED") {
+			return true
+		}
+	}
+	return false
+}
+
+// addDaysExceptSundays returns the date n days after d not counting Sundays
+func addDaysExceptSundays(d civil.Date, n int) civil.Date {
+	for n > 0 {
+		d = d.AddDays(1)
+		if d.In(time.UTC).Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return d
+}
+
+// GovernorDeadline computes when the governor must act on a delivered bill and, as of today,
+// the outcome. It returns nil if the bill has not been delivered to the governor.
+func (c SessionCalendar) GovernorDeadline(b Bill, today civil.Date) *GovernorDeadline {
+	delivered := b.deliveredDate()
+	if !delivered.IsValid() {
+		return nil
+	}
+	d := &GovernorDeadline{
+		Delivered: delivered,
+		Rule:      TenDayRule,
+		Deadline:  addDaysExceptSundays(delivered, 10),
+		Outcome:   GovernorPending,
+	}
+	// an adjournment before the ten days expire prevents the return of the bill and the governor has
+	// thirty days after adjournment to act; a bill presented after adjournment has thirty days
+	// after it is presented (Art. IV §7)
+	if adjourned, ok := c.Adjournments[delivered.Year]; ok && adjourned.Before(d.Deadline) {
+		d.Rule = ThirtyDayRule
+		start := adjourned
+		if delivered.After(adjourned) {
+			start = delivered
+		}
+		if thirty := start.AddDays(30); thirty.After(d.Deadline) {
+			d.Deadline = thirty
+		}
+	}
+	switch {
+	case b.Signed || b.SignedDate().IsValid():
+		d.Outcome = GovernorSigned
+	case b.vetoed():
+		d.Outcome = GovernorVetoed
+	case today.After(d.Deadline) && d.Rule == TenDayRule:
+		d.Outcome = LawWithoutSignature
+	case today.After(d.Deadline):
+		d.Outcome = PocketVetoed
+	}
+	return d
+}
@@ -0,0 +1,57 @@
+package nysenateapi
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(y, m, d int) civil.Date {
+	return civil.Date{Year: y, Month: time.Month(m), Day: d}
+}
+
+func TestGovernorDeadline(t *testing.T) {
+	cal := SessionCalendar{Adjournments: map[int]civil.Date{2022: date(2022, 6, 4)}}
+	delivered := func(d civil.Date, actions ...Action) Bill {
+		return Bill{Actions: append([]Action{{Text: "DELIVERED TO GOVERNOR", Date: d}}, actions...)}
+	}
+	type testCase struct {
+		name     string
+		bill     Bill
+		today    civil.Date
+		rule     DeadlineRule
+		deadline civil.Date
+		outcome  GovernorOutcome
+	}
+	tests := []testCase{
+		// Friday 2023-12-01; Sundays 12/3 and 12/10 excepted
+		{"pending", delivered(date(2023, 12, 1)), date(2023, 12, 5), TenDayRule, date(2023, 12, 13), GovernorPending},
+		{"law without signature", delivered(date(2023, 12, 1)), date(2023, 12, 14), TenDayRule, date(2023, 12, 13), LawWithoutSignature},
+		{"signed", delivered(date(2023, 12, 1), Action{Text: "SIGNED CHAP.580", Date: date(2023, 12, 8)}), date(2023, 12, 14), TenDayRule, date(2023, 12, 13), GovernorSigned},
+		{"vetoed", delivered(date(2023, 12, 1), Action{Text: "VETOED MEMO.120", Date: date(2023, 12, 8)}), date(2023, 12, 14), TenDayRule, date(2023, 12, 13), GovernorVetoed},
+		{"adjourned", delivered(date(2022, 6, 1)), date(2022, 6, 20), ThirtyDayRule, date(2022, 7, 4), GovernorPending},
+		{"pocket veto", delivered(date(2022, 6, 1)), date(2022, 7, 5), ThirtyDayRule, date(2022, 7, 4), PocketVetoed},
+		// after the June 4 adjournment the governor has thirty days from delivery
+		{"delivered after adjournment", delivered(date(2022, 7, 20)), date(2022, 8, 2), ThirtyDayRule, date(2022, 8, 19), GovernorPending},
+		{"pocket veto after adjournment", delivered(date(2022, 7, 20)), date(2022, 8, 20), ThirtyDayRule, date(2022, 8, 19), PocketVetoed},
+		// delivered before adjournment with the ten days expiring after it
+		{"delivered days before adjournment", delivered(date(2022, 6, 2)), date(2022, 7, 1), ThirtyDayRule, date(2022, 7, 4), GovernorPending},
+		{"in session before adjournment", delivered(date(2022, 5, 1)), date(2022, 5, 20), TenDayRule, date(2022, 5, 12), LawWithoutSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := cal.GovernorDeadline(tc.bill, tc.today)
+			if assert.NotNil(t, d) {
+				assert.Equal(t, tc.rule, d.Rule)
+				assert.Equal(t, tc.deadline, d.Deadline)
+				assert.Equal(t, tc.outcome, d.Outcome)
+			}
+		})
+	}
+
+	assert.Nil(t, cal.GovernorDeadline(Bill{}, date(2023, 1, 1)))
+	milestone := Bill{Milestones: []Milestone{{Type: "DELIVERED_TO_GOV", Date: date(2023, 12, 1)}}}
+	assert.Equal(t, date(2023, 12, 1), cal.GovernorDeadline(milestone, date(2023, 12, 1)).Delivered)
+}
@@ -0,0 +1,368 @@
+package nysenateapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffSegment is a run of words with the same DiffOp
+type DiffSegment struct {
+	Op   DiffOp `json:"Op"`
+	Text string `json:"Text"`
+}
+
+// AmendmentDiff is a word level diff between two amendment versions of a bill
+type AmendmentDiff struct {
+	PrintNo     string        `json:"PrintNo,omitempty"`
+	Session     int           `json:"Session,omitempty"`
+	FromVersion string        `json:"FromVersion"`
+	ToVersion   string        `json:"ToVersion"`
+	Segments    []DiffSegment `json:"Segments,omitempty"`
+}
+
+// DiffHunk is a single change with surrounding context
+type DiffHunk struct {
+	// Word is the offset (in words) of the change in the "from" version
+	Word     int    `json:"Word"`
+	Before   string `json:"Before,omitempty"`
+	Deleted  string `json:"Deleted,omitempty"`
+	Inserted string `json:"Inserted,omitempty"`
+	After    string `json:"After,omitempty"`
+}
+
+// DiffAmendments compares the text of two amendment versions of a bill ("" is the original print)
+func (a *API) DiffAmendments(ctx context.Context, session, printNo, fromVersion, toVersion string) (*AmendmentDiff, error) {
+	bill, err := a.api.GetBillText(ctx, session, printNo, verboseapi.PlainText)
+	if err != nil {
+		return nil, err
+	}
+	if bill == nil {
+		return nil, nil
+	}
+	from, ok := bill.Amendments.Items[fromVersion]
+	if !ok {
+		return nil, fmt.Errorf("version %q of %s-%s not found", fromVersion, printNo, session)
+	}
+	to, ok := bill.Amendments.Items[toVersion]
+	if !ok {
+		return nil, fmt.Errorf("version %q of %s-%s not found", toVersion, printNo, session)
+	}
+	d := NewAmendmentDiff(from, to)
+	d.PrintNo = bill.BasePrintNo
+	d.Session = bill.Session
+	return &d, nil
+}
+
+// NewAmendmentDiff diffs the body text of two amendments. Line numbers, page headers and
+// hyphenation at line breaks are removed before comparing.
+func NewAmendmentDiff(from, to verboseapi.Amendment) AmendmentDiff {
+	return AmendmentDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Segments:    DiffWords(billWords(from.FullText), billWords(to.FullText)),
+	}
+}
+
+// billWords returns the words in the numbered lines of a bill
+func billWords(s string) []string {
+	var words []string
+	h := newHyphenation(s)
+	for _, p := range ParseBillText(s) {
+		for _, l := range p.Lines {
+			if l.Number == 0 {
+				continue
+			}
+			for i, w := range strings.Fields(l.Text) {
+				// rejoin words hyphenated across a line break i.e. "pedes-" "trian"
+				if i == 0 && len(words) > 0 && h.breaks(words[len(words)-1], w) {
+					words[len(words)-1] = h.join(words[len(words)-1], w)
+					continue
+				}
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
+
+// DiffWords returns the segments needed to transform a into b
+func DiffWords(a, b []string) []DiffSegment {
+	var segments []DiffSegment
+	var equal, deleted, inserted []string
+	add := func(op DiffOp, words []string) {
+		if len(words) > 0 {
+			segments = append(segments, DiffSegment{Op: op, Text: strings.Join(words, " ")})
+		}
+	}
+	// present deletions before insertions for each change
+	flushChange := func() {
+		add(DiffDelete, deleted)
+		add(DiffInsert, inserted)
+		deleted, inserted = nil, nil
+	}
+	for _, e := range myers(a, b) {
+		switch e.op {
+		case DiffEqual:
+			flushChange()
+			equal = append(equal, e.word)
+		case DiffDelete, DiffInsert:
+			if len(equal) > 0 {
+				add(DiffEqual, equal)
+				equal = nil
+			}
+			if e.op == DiffDelete {
+				deleted = append(deleted, e.word)
+			} else {
+				inserted = append(inserted, e.word)
+			}
+		}
+	}
+	add(DiffEqual, equal)
+	flushChange()
+	return segments
+}
+
+type edit struct {
+	op   DiffOp
+	word string
+}
+
+// myers implements the linear space variant of the O(ND) diff algorithm from "An O(ND) Difference
+// Algorithm and Its Variations". Each step finds the middle snake of the edit graph and recurses on
+// either side of it so memory is O(N+M) even for a complete rewrite.
+func myers(a, b []string) []edit {
+	// compare words as integers
+	ids := make(map[string]int)
+	intern := func(words []string) []int {
+		o := make([]int, len(words))
+		for i, w := range words {
+			id, ok := ids[w]
+			if !ok {
+				id = len(ids)
+				ids[w] = id
+			}
+			o[i] = id
+		}
+		return o
+	}
+	size := 2*((len(a)+len(b)+1)/2) + 3
+	d := &differ{
+		a: a, b: b,
+		ai: intern(a), bi: intern(b),
+		vf: make([]int, size),
+		vb: make([]int, size),
+	}
+	d.edits = make([]edit, 0, max(len(a), len(b)))
+	d.diff(0, len(a), 0, len(b))
+	return d.edits
+}
+
+type differ struct {
+	a, b   []string
+	ai, bi []int
+	vf, vb []int
+	edits  []edit
+}
+
+// diff appends the edits to transform a[aLo:aHi] into b[bLo:bHi]
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.ai[aLo] == d.bi[bLo] {
+		d.edits = append(d.edits, edit{DiffEqual, d.a[aLo]})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi && bLo < bHi && d.ai[aHi-1] == d.bi[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+	switch {
+	case aLo == aHi:
+		for _, w := range d.b[bLo:bHi] {
+			d.edits = append(d.edits, edit{DiffInsert, w})
+		}
+	case bLo == bHi:
+		for _, w := range d.a[aLo:aHi] {
+			d.edits = append(d.edits, edit{DiffDelete, w})
+		}
+	default:
+		// with a common prefix and suffix removed the edit distance is at least 2 so both
+		// sides of the middle snake are smaller problems
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.diff(aLo, x, bLo, y)
+		for _, w := range d.a[x:u] {
+			d.edits = append(d.edits, edit{DiffEqual, w})
+		}
+		d.diff(u, aHi, v, bHi)
+	}
+	for _, w := range d.a[aHi : aHi+suffix] {
+		d.edits = append(d.edits, edit{DiffEqual, w})
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake of an optimal path through
+// the edit graph of a[aLo:aHi] and b[bLo:bHi] by searching forward from the start and backward from the end
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	a, b := d.ai[aLo:aHi], d.bi[bLo:bHi]
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	// vf[offset+k] is the furthest x on forward diagonal k; vb[offset+k] the furthest distance from
+	// the end on reverse diagonal k (forward diagonal delta-k)
+	vf, vb := d.vf[:2*limit+3], d.vb[:2*limit+3]
+	vf[offset+1], vb[offset+1] = 0, 0
+	for step := 0; step <= limit; step++ {
+		for k := -step; k <= step; k += 2 {
+			var fx int
+			if k == -step || (k != step && vf[offset+k-1] < vf[offset+k+1]) {
+				fx = vf[offset+k+1]
+			} else {
+				fx = vf[offset+k-1] + 1
+			}
+			fy := fx - k
+			sx, sy := fx, fy
+			for fx < n && fy < m && a[fx] == b[fy] {
+				fx++
+				fy++
+			}
+			vf[offset+k] = fx
+			if rk := delta - k; odd && rk >= -(step-1) && rk <= step-1 && fx+vb[offset+rk] >= n {
+				return aLo + sx, bLo + sy, aLo + fx, bLo + fy
+			}
+		}
+		for k := -step; k <= step; k += 2 {
+			var rx int
+			if k == -step || (k != step && vb[offset+k-1] < vb[offset+k+1]) {
+				rx = vb[offset+k+1]
+			} else {
+				rx = vb[offset+k-1] + 1
+			}
+			ry := rx - k
+			sx, sy := rx, ry
+			for rx < n && ry < m && a[n-1-rx] == b[m-1-ry] {
+				rx++
+				ry++
+			}
+			vb[offset+k] = rx
+			if fk := delta - k; !odd && fk >= -step && fk <= step && vf[offset+fk]+rx >= n {
+				return aLo + n - rx, bLo + m - ry, aLo + n - sx, bLo + m - sy
+			}
+		}
+	}
+	panic("nysenateapi: no middle snake")
+}
+
+// Changed reports if the two versions differ
+func (d AmendmentDiff) Changed() bool {
+	for _, s := range d.Segments {
+		if s.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// Hunks returns each change with up to context words before and after
+func (d AmendmentDiff) Hunks(context int) []DiffHunk {
+	var hunks []DiffHunk
+	word := 0
+	for i := 0; i < len(d.Segments); i++ {
+		s := d.Segments[i]
+		if s.Op == DiffEqual {
+			word += len(strings.Fields(s.Text))
+			continue
+		}
+		h := DiffHunk{Word: word}
+		if i > 0 {
+			w := strings.Fields(d.Segments[i-1].Text)
+			h.Before = strings.Join(w[max(0, len(w)-context):], " ")
+		}
+		for ; i < len(d.Segments) && d.Segments[i].Op != DiffEqual; i++ {
+			switch d.Segments[i].Op {
+			case DiffDelete:
+				h.Deleted = d.Segments[i].Text
+				word += len(strings.Fields(d.Segments[i].Text))
+			case DiffInsert:
+				h.Inserted = d.Segments[i].Text
+			}
+		}
+		if i < len(d.Segments) {
+			w := strings.Fields(d.Segments[i].Text)
+			h.After = strings.Join(w[:min(context, len(w))], " ")
+			i--
+		}
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// Unified renders each change hunk in a wdiff style format
+//
+//	@@ word 120 @@
+//	... context [-deleted words-] {+inserted words+} context ...
+func (d AmendmentDiff) Unified(context int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s%s\n+++ %s%s\n", d.PrintNo, d.FromVersion, d.PrintNo, d.ToVersion)
+	for _, h := range d.Hunks(context) {
+		fmt.Fprintf(&b, "@@ word %d @@\n", h.Word)
+		var parts []string
+		if h.Before != "" {
+			parts = append(parts, "..."+h.Before)
+		}
+		if h.Deleted != "" {
+			parts = append(parts, "[-"+h.Deleted+"-]")
+		}
+		if h.Inserted != "" {
+			parts = append(parts, "{+"+h.Inserted+"+}")
+		}
+		if h.After != "" {
+			parts = append(parts, h.After+"...")
+		}
+		b.WriteString(strings.Join(parts, " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// HTML renders the full text with <del> and <ins> markup
+func (d AmendmentDiff) HTML() string {
+	var parts []string
+	for _, s := range d.Segments {
+		switch s.Op {
+		case DiffEqual:
+			parts = append(parts, html.EscapeString(s.Text))
+		case DiffDelete:
+			parts = append(parts, "<del>"+html.EscapeString(s.Text)+"</del>")
+		case DiffInsert:
+			parts = append(parts, "<ins>"+html.EscapeString(s.Text)+"</ins>")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// JSON renders the change hunks as JSON
+func (d AmendmentDiff) JSON(context int) ([]byte, error) {
+	return json.Marshal(struct {
+		PrintNo     string     `json:"PrintNo,omitempty"`
+		Session     int        `json:"Session,omitempty"`
+		FromVersion string     `json:"FromVersion"`
+		ToVersion   string     `json:"ToVersion"`
+		Hunks       []DiffHunk `json:"Hunks"`
+	}{d.PrintNo, d.Session, d.FromVersion, d.ToVersion, d.Hunks(context)})
+}
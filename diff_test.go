@@ -0,0 +1,148 @@
+package nysenateapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffWords(t *testing.T) {
+	type testCase struct {
+		a, b string
+		want []DiffSegment
+	}
+	tests := []testCase{
+		{"a b c", "a b c", []DiffSegment{{DiffEqual, "a b c"}}},
+		{"", "a", []DiffSegment{{DiffInsert, "a"}}},
+		{"a", "", []DiffSegment{{DiffDelete, "a"}}},
+		{"a b c", "a x c", []DiffSegment{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}}},
+		{"the quick brown fox", "the slow brown dog jumps", []DiffSegment{
+			{DiffEqual, "the"}, {DiffDelete, "quick"}, {DiffInsert, "slow"}, {DiffEqual, "brown"}, {DiffDelete, "fox"}, {DiffInsert, "dog jumps"},
+		}},
+		{"a b c d", "b c d e", []DiffSegment{{DiffDelete, "a"}, {DiffEqual, "b c d"}, {DiffInsert, "e"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+"|"+tc.b, func(t *testing.T) {
+			got := DiffWords(strings.Fields(tc.a), strings.Fields(tc.b))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewAmendmentDiff(t *testing.T) {
+	from := verboseapi.Amendment{FullText: `
+     1    Section 1. Any driver who fails to yield to a pedes-
+     2  trian shall be fined fifty dollars.
+     3    § 2. This act shall take effect immediately.
+`}
+	to := verboseapi.Amendment{FullText: `
+     1    Section 1. Any driver who fails to yield to a pedestrian or
+     2  cyclist shall be fined one hundred dollars.
+     3    § 2. This act shall take effect on the ninetieth day after it shall have become a law.
+`}
+	to.Version = "A"
+	d := NewAmendmentDiff(from, to)
+	d.PrintNo = "S2304"
+	require.True(t, d.Changed())
+
+	hunks := d.Hunks(2)
+	require.Len(t, hunks, 3)
+	assert.Equal(t, DiffHunk{Word: 11, Before: "a pedestrian", Deleted: "", Inserted: "or cyclist", After: "shall be"}, hunks[0])
+	assert.Equal(t, DiffHunk{Word: 14, Before: "be fined", Deleted: "fifty", Inserted: "one hundred", After: "dollars. §"}, hunks[1])
+
+	assert.Equal(t, `--- S2304
++++ S2304A
+@@ word 11 @@
+...a pedestrian {+or cyclist+} shall be...
+@@ word 14 @@
+...be fined [-fifty-] {+one hundred+} dollars. §...
+@@ word 23 @@
+...take effect [-immediately.-] {+on the ninetieth day after it shall have become a law.+}
+`, d.Unified(2))
+
+	assert.Contains(t, d.HTML(), "fined <del>fifty</del> <ins>one hundred</ins> dollars.")
+
+	b, err := d.JSON(1)
+	require.NoError(t, err)
+	var out struct{ Hunks []DiffHunk }
+	require.NoError(t, json.Unmarshal(b, &out))
+	assert.Len(t, out.Hunks, 3)
+}
+
+func TestDiffWordsLarge(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 5000; i++ {
+		w := fmt.Sprintf("w%d", i)
+		if i%7 != 0 {
+			a = append(a, w)
+		}
+		if i%11 != 0 {
+			b = append(b, w)
+		}
+	}
+	var from, to []string
+	for _, s := range DiffWords(a, b) {
+		switch s.Op {
+		case DiffEqual:
+			from = append(from, strings.Fields(s.Text)...)
+			to = append(to, strings.Fields(s.Text)...)
+		case DiffDelete:
+			from = append(from, strings.Fields(s.Text)...)
+		case DiffInsert:
+			to = append(to, strings.Fields(s.Text)...)
+		}
+	}
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+}
+
+func TestDiffWordsRewrite(t *testing.T) {
+	// a complete rewrite has the largest possible edit distance
+	var a, b []string
+	for i := 0; i < 4000; i++ {
+		a = append(a, fmt.Sprintf("old%d", i))
+		b = append(b, fmt.Sprintf("new%d", i))
+	}
+	var segments []DiffSegment
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	segments = DiffWords(a, b)
+	runtime.ReadMemStats(&after)
+	require.Len(t, segments, 2)
+	assert.Equal(t, DiffDelete, segments[0].Op)
+	assert.Equal(t, DiffInsert, segments[1].Op)
+	if n := after.TotalAlloc - before.TotalAlloc; n > 4<<20 {
+		t.Errorf("allocated %d bytes diffing %d words", n, len(a)+len(b))
+	}
+
+	// interleaved changes
+	for i := range b {
+		if i%2 == 0 {
+			b[i] = a[i]
+		}
+	}
+	allocs := testing.AllocsPerRun(5, func() { DiffWords(a, b) })
+	if allocs > 10000 {
+		t.Errorf("got %v allocations", allocs)
+	}
+}
+
+func TestBillWordsHyphenation(t *testing.T) {
+	text := `
+     1    Section 1. A self-
+     2  insured employer shall yield to a pedes-
+     3  trian in a cross-walk within twenty-
+     4  five feet of a cross-
+     5  walk. The non-
+     6  profit may post-
+     7  pone.
+`
+	assert.Equal(t, "Section 1. A self-insured employer shall yield to a pedestrian in a cross-walk within twenty-five feet of a cross-walk. The nonprofit may postpone.",
+		strings.Join(billWords(text), " "))
+}
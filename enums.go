@@ -0,0 +1,164 @@
+package nysenateapi
+
+import (
+	"strings"
+)
+
+// BillStatus mirrors the OpenLegislation statusType values. Values not listed here are
+// preserved as-is; use Known to detect them.
+type BillStatus string
+
+const (
+	StatusIntroduced          BillStatus = "INTRODUCED"
+	StatusInAssemblyCommittee BillStatus = "IN_ASSEMBLY_COMM"
+	StatusInSenateCommittee   BillStatus = "IN_SENATE_COMM"
+	StatusAssemblyFloor       BillStatus = "ASSEMBLY_FLOOR"
+	StatusSenateFloor         BillStatus = "SENATE_FLOOR"
+	StatusPassedAssembly      BillStatus = "PASSED_ASSEMBLY"
+	StatusPassedSenate        BillStatus = "PASSED_SENATE"
+	StatusDeliveredToGovernor BillStatus = "DELIVERED_TO_GOV"
+	StatusSignedByGovernor    BillStatus = "SIGNED_BY_GOV"
+	StatusVetoed              BillStatus = "VETOED"
+	StatusStricken            BillStatus = "STRICKEN"
+	StatusLost                BillStatus = "LOST"
+	StatusSubstituted         BillStatus = "SUBSTITUTED"
+	StatusAdopted             BillStatus = "ADOPTED"
+)
+
+var billStatuses = map[string]BillStatus{
+	// statusDesc values
+	"IN SENATE COMMITTEE":     StatusInSenateCommittee,
+	"IN ASSEMBLY COMMITTEE":   StatusInAssemblyCommittee,
+	"SENATE FLOOR CALENDAR":   StatusSenateFloor,
+	"SENATE FLOOR CALENDER":   StatusSenateFloor,
+	"ASSEMBLY FLOOR CALENDAR": StatusAssemblyFloor,
+	"ASSEMBLY FLOOR CALENDER": StatusAssemblyFloor,
+	"DELIVERED TO GOVERNOR":   StatusDeliveredToGovernor,
+	"SIGNED BY GOVERNOR":      StatusSignedByGovernor,
+	"VETOED BY GOVERNOR":      StatusVetoed,
+}
+
+func init() {
+	for _, s := range []BillStatus{StatusIntroduced, StatusInAssemblyCommittee, StatusInSenateCommittee,
+		StatusAssemblyFloor, StatusSenateFloor, StatusPassedAssembly, StatusPassedSenate,
+		StatusDeliveredToGovernor, StatusSignedByGovernor, StatusVetoed, StatusStricken, StatusLost,
+		StatusSubstituted, StatusAdopted} {
+		billStatuses[string(s)] = s
+		billStatuses[strings.ReplaceAll(string(s), "_", " ")] = s
+	}
+}
+
+// ParseBillStatus parses a statusType ("PASSED_SENATE") or statusDesc ("Passed Senate")
+func ParseBillStatus(s string) BillStatus {
+	if v, ok := billStatuses[normalizeEnum(s)]; ok {
+		return v
+	}
+	return BillStatus(s)
+}
+
+// Known reports if s is one of the defined BillStatus values
+func (s BillStatus) Known() bool {
+	v, ok := billStatuses[string(s)]
+	return ok && v == s
+}
+
+func (s *BillStatus) UnmarshalText(b []byte) error {
+	*s = ParseBillStatus(string(b))
+	return nil
+}
+
+// VoteKind is the kind of vote. Values not listed here are preserved as-is.
+type VoteKind string
+
+const (
+	CommitteeVote VoteKind = "COMMITTEE"
+	FloorVote     VoteKind = "FLOOR"
+)
+
+// assemblyCommitteeActions are the Assembly committee vote captions ("Action: Favorable refer to committee Ways and Means")
+var assemblyCommitteeActions = []string{"FAVORABLE", "HELD FOR CONSIDERATION", "DEFEATED", "REPORTED", "UNFAVORABLE"}
+
+// ParseVoteKind parses OpenLegislation vote types (COMMITTEE, FLOOR) and Assembly committee vote captions
+func ParseVoteKind(s string) VoteKind {
+	n := normalizeEnum(s)
+	switch n {
+	case "COMMITTEE", "COMMITTEE VOTES":
+		return CommitteeVote
+	case "FLOOR", "FLOOR VOTES":
+		return FloorVote
+	}
+	for _, prefix := range assemblyCommitteeActions {
+		if strings.HasPrefix(n, prefix) {
+			return CommitteeVote
+		}
+	}
+	return VoteKind(s)
+}
+
+// Known reports if k is one of the defined VoteKind values
+func (k VoteKind) Known() bool {
+	return k == CommitteeVote || k == FloorVote
+}
+
+func (k *VoteKind) UnmarshalText(b []byte) error {
+	*k = ParseVoteKind(string(b))
+	return nil
+}
+
+// VoteValue is how a member voted. Values not listed here are preserved as-is.
+type VoteValue string
+
+const (
+	VoteAye       VoteValue = "Aye"
+	VoteNay       VoteValue = "Nay"
+	VoteExcused   VoteValue = "Excused"
+	VoteAbsent    VoteValue = "Absent"
+	VoteAbstained VoteValue = "Abstained"
+)
+
+var voteValues = map[string]VoteValue{
+	// OpenLegislation vote codes
+	"AYE": VoteAye,
+	"NAY": VoteNay,
+	"EXC": VoteExcused,
+	"ABS": VoteAbsent,
+	"ABD": VoteAbstained,
+	// nyassembly.gov codes
+	"Y":  VoteAye,
+	"N":  VoteNay,
+	"NO": VoteNay,
+	"ER": VoteExcused,
+	"AB": VoteAbsent,
+	// descriptions
+	"YES":       VoteAye,
+	"EXCUSED":   VoteExcused,
+	"ABSENT":    VoteAbsent,
+	"ABSTAIN":   VoteAbstained,
+	"ABSTAINED": VoteAbstained,
+}
+
+// ParseVoteValue parses OpenLegislation vote codes (AYE, NAY, EXC, ...) and nyassembly.gov codes (Y, NO, ER, ...)
+func ParseVoteValue(s string) VoteValue {
+	if v, ok := voteValues[normalizeEnum(s)]; ok {
+		return v
+	}
+	return VoteValue(s)
+}
+
+// Known reports if v is one of the defined VoteValue values
+func (v VoteValue) Known() bool {
+	switch v {
+	case VoteAye, VoteNay, VoteExcused, VoteAbsent, VoteAbstained:
+		return true
+	}
+	return false
+}
+
+func (v *VoteValue) UnmarshalText(b []byte) error {
+	*v = ParseVoteValue(string(b))
+	return nil
+}
+
+func normalizeEnum(s string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(s)), " ")
+}
@@ -0,0 +1,81 @@
+package nysenateapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBillStatus(t *testing.T) {
+	tests := map[string]BillStatus{
+		"PASSED_SENATE":         StatusPassedSenate,
+		"Passed Senate":         StatusPassedSenate,
+		"In Senate Committee":   StatusInSenateCommittee,
+		"IN_ASSEMBLY_COMM":      StatusInAssemblyCommittee,
+		"Senate Floor Calender": StatusSenateFloor,
+		"Delivered to Governor": StatusDeliveredToGovernor,
+		"SIGNED_BY_GOV":         StatusSignedByGovernor,
+		"NEW_STATUS":            BillStatus("NEW_STATUS"),
+	}
+	for in, want := range tests {
+		got := ParseBillStatus(in)
+		assert.Equal(t, want, got, in)
+		assert.Equal(t, in != "NEW_STATUS", got.Known(), in)
+	}
+}
+
+func TestParseVoteKind(t *testing.T) {
+	tests := map[string]VoteKind{
+		"COMMITTEE":              CommitteeVote,
+		"FLOOR":                  FloorVote,
+		"Floor Votes":            FloorVote,
+		"Held for Consideration": CommitteeVote,
+		"Favorable refer to committee Ways and Means": CommitteeVote,
+		"Something Else": VoteKind("Something Else"),
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, ParseVoteKind(in), in)
+	}
+	assert.False(t, VoteKind("Something Else").Known())
+}
+
+func TestParseVoteValue(t *testing.T) {
+	tests := map[string]VoteValue{
+		"AYE":     VoteAye,
+		"Aye":     VoteAye,
+		"Y":       VoteAye,
+		"NAY":     VoteNay,
+		"NO":      VoteNay,
+		"EXC":     VoteExcused,
+		"ER":      VoteExcused,
+		"Excused": VoteExcused,
+		"ABS":     VoteAbsent,
+		"Absent":  VoteAbsent,
+		"ABD":     VoteAbstained,
+		"??":      VoteValue("??"),
+	}
+	for in, want := range tests {
+		got := ParseVoteValue(in)
+		assert.Equal(t, want, got, in)
+		assert.Equal(t, in != "??", got.Known(), in)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	v := VoteEntry{ID: 1, Vote: VoteAye}
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ID":1,"Vote":"Aye"}`, string(b))
+
+	var got struct {
+		Vote   VoteValue
+		Kind   VoteKind
+		Status BillStatus
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"Vote":"Y","Kind":"Floor","Status":"Passed Assembly"}`), &got))
+	assert.Equal(t, VoteAye, got.Vote)
+	assert.Equal(t, FloorVote, got.Kind)
+	assert.Equal(t, StatusPassedAssembly, got.Status)
+}
@@ -0,0 +1,116 @@
+package nysenateapi
+
+import (
+	"context"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+// Approval is a governor's approval memo
+type Approval struct {
+	Bill    BillReference `json:"Bill"`
+	Year    int           `json:"Year"`
+	Number  int           `json:"Number"`
+	Chapter int           `json:"Chapter,omitempty"`
+	Signer  string        `json:"Signer,omitempty"`
+	Text    string        `json:"Text,omitempty"`
+}
+
+// Veto is a governor's veto message
+type Veto struct {
+	Bill       BillReference `json:"Bill"`
+	Year       int           `json:"Year"`
+	Number     int           `json:"Number"`
+	Type       string        `json:"Type,omitempty"` // STANDARD, LINE_ITEM
+	Chapter    int           `json:"Chapter,omitempty"`
+	Signer     string        `json:"Signer,omitempty"`
+	SignedDate civil.Date    `json:"SignedDate,omitempty"`
+	MemoText   string        `json:"MemoText,omitempty"`
+}
+
+func newApproval(a verboseapi.ApprovalMessage) *Approval {
+	if a.ApprovalNumber == 0 && a.Chapter == 0 {
+		return nil
+	}
+	return &Approval{
+		Bill:    BillReference{PrintNo: a.BillID.BasePrintNo, Session: a.BillID.Session},
+		Year:    a.Year,
+		Number:  a.ApprovalNumber,
+		Chapter: a.Chapter,
+		Signer:  a.Signer,
+		Text:    a.Text,
+	}
+}
+
+func newVeto(v verboseapi.VetoMessage) Veto {
+	o := Veto{
+		Bill:     BillReference{PrintNo: v.BillID.BasePrintNo, Session: v.BillID.Session},
+		Year:     v.Year,
+		Number:   v.VetoNumber,
+		Type:     v.VetoType,
+		Chapter:  v.Chapter,
+		Signer:   v.Signer,
+		MemoText: v.MemoText,
+	}
+	if v.SignedDate != "" {
+		o.SignedDate = civil.DateOf(parseTime(v.SignedDate))
+	}
+	return o
+}
+
+// chapterFromActions finds the chapter number in a signing action like "SIGNED CHAP.45"
+func chapterFromActions(actions []Action) int {
+	for i := len(actions) - 1; i >= 0; i-- {
+		if e := ClassifyAction(actions[i]); e.Type == ActionSignature && e.Chapter != 0 {
+			return e.Chapter
+		}
+	}
+	return 0
+}
+
+// Approvals returns the governor's approval memos for a year
+func (a *API) Approvals(ctx context.Context, year int) ([]Approval, error) {
+	var out []Approval
+	offset := 1
+	for {
+		resp, err := a.api.GetApprovals(ctx, year, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return out, nil
+		}
+		for _, m := range resp.Result.Items {
+			if ap := newApproval(m); ap != nil {
+				out = append(out, *ap)
+			}
+		}
+		if resp.OffsetEnd >= resp.Total || len(resp.Result.Items) == 0 {
+			return out, nil
+		}
+		offset = resp.OffsetEnd + 1
+	}
+}
+
+// Vetoes returns the governor's veto messages for a year
+func (a *API) Vetoes(ctx context.Context, year int) ([]Veto, error) {
+	var out []Veto
+	offset := 1
+	for {
+		resp, err := a.api.GetVetoes(ctx, year, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return out, nil
+		}
+		for _, v := range resp.Result.Items {
+			out = append(out, newVeto(v))
+		}
+		if resp.OffsetEnd >= resp.Total || len(resp.Result.Items) == 0 {
+			return out, nil
+		}
+		offset = resp.OffsetEnd + 1
+	}
+}
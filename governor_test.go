@@ -0,0 +1,45 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBillGovernorActions(t *testing.T) {
+	b := &verboseapi.Bill{BasePrintNo: "S1234", Session: 2023, Signed: true}
+	b.SubstitutedBy = verboseapi.BillID{BasePrintNo: "A5678", Session: 2023}
+	b.ApprovalMessage = verboseapi.ApprovalMessage{
+		BillID:         verboseapi.BillID{BasePrintNo: "S1234", Session: 2023},
+		Year:           2023,
+		ApprovalNumber: 12,
+		Chapter:        123,
+		Signer:         "KATHY HOCHUL",
+	}
+	bill := newBill(b)
+	assert.True(t, bill.Signed)
+	assert.Equal(t, 123, bill.Chapter)
+	assert.Equal(t, "A5678", bill.SubstitutedBy)
+	assert.Equal(t, &Approval{
+		Bill:    BillReference{PrintNo: "S1234", Session: 2023},
+		Year:    2023,
+		Number:  12,
+		Chapter: 123,
+		Signer:  "KATHY HOCHUL",
+	}, bill.Approval)
+
+	// chapter from actions when there is no approval memo
+	b = &verboseapi.Bill{BasePrintNo: "S1", Session: 2023, Signed: true}
+	b.Actions.Items = []verboseapi.BillAction{{Text: "SIGNED CHAP.45"}, {Text: "CHAPTER AMENDMENT TO CHAP.200 OF 2022"}}
+	bill = newBill(b)
+	assert.Nil(t, bill.Approval)
+	assert.Equal(t, 45, bill.Chapter)
+
+	b = &verboseapi.Bill{BasePrintNo: "S2", Session: 2023}
+	b.VetoMessages.Items = []verboseapi.VetoMessage{{VetoNumber: 7, Year: 2023, VetoType: "STANDARD", SignedDate: "2023-12-22"}}
+	bill = newBill(b)
+	assert.Equal(t, 0, bill.Chapter)
+	assert.Equal(t, []Veto{{Year: 2023, Number: 7, Type: "STANDARD", SignedDate: civil.Date{Year: 2023, Month: 12, Day: 22}}}, bill.Vetoes)
+}
@@ -0,0 +1,180 @@
+package ics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi"
+)
+
+// Source provides committee meetings and session days; it is satisfied by *nysenateapi.API
+type Source interface {
+	CommitteeMeetings(ctx context.Context, from, to time.Time) ([]nysenateapi.CommitteeMeeting, error)
+	SessionDays(ctx context.Context, year int) ([]nysenateapi.SessionDay, error)
+}
+
+// Handler serves subscribable .ics feeds
+//
+//	GET /committee/{committee}.ics
+//	GET /bill/{session}/{printNo}.ics
+//
+// Feeds cover meetings and session days from Before the current time through After the current time.
+// Session days for a year are cached for SessionDaysTTL.
+type Handler struct {
+	Source         Source
+	Before         time.Duration
+	After          time.Duration
+	SessionDaysTTL time.Duration
+	// Logger for upstream and write errors (default slog.Default())
+	Logger *slog.Logger
+
+	now func() time.Time
+	mux *http.ServeMux
+
+	mutex       sync.Mutex
+	sessionDays map[int]cachedSessionDays
+}
+
+type cachedSessionDays struct {
+	days    []nysenateapi.SessionDay
+	fetched time.Time
+}
+
+func NewHandler(s Source) *Handler {
+	h := &Handler{
+		Source:         s,
+		Before:         30 * 24 * time.Hour,
+		After:          90 * 24 * time.Hour,
+		SessionDaysTTL: time.Hour,
+		now:            time.Now,
+		mux:            http.NewServeMux(),
+		sessionDays:    make(map[int]cachedSessionDays),
+	}
+	h.mux.HandleFunc("GET /committee/{committee}", h.committee)
+	h.mux.HandleFunc("GET /bill/{session}/{printNo}", h.bill)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) window() (time.Time, time.Time) {
+	now := h.now()
+	return now.Add(-1 * h.Before), now.Add(h.After)
+}
+
+func (h *Handler) committee(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("committee"), ".ics")
+	if !ok || name == "" {
+		http.NotFound(w, r)
+		return
+	}
+	from, to := h.window()
+	meetings, err := h.Source.CommitteeMeetings(r.Context(), from, to)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+	cal := Calendar{Name: fmt.Sprintf("%s Committee", name)}
+	for _, m := range meetings {
+		if strings.EqualFold(m.Committee, name) {
+			cal.Events = append(cal.Events, CommitteeMeetingEvent(m))
+		}
+	}
+	h.write(w, r, cal)
+}
+
+func (h *Handler) bill(w http.ResponseWriter, r *http.Request) {
+	printNo, ok := strings.CutSuffix(r.PathValue("printNo"), ".ics")
+	session, err := strconv.Atoi(r.PathValue("session"))
+	if !ok || printNo == "" || err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	printNo = strings.ToUpper(printNo)
+	bill := nysenateapi.BillReference{PrintNo: printNo, Session: session}
+
+	from, to := h.window()
+	meetings, err := h.Source.CommitteeMeetings(r.Context(), from, to)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+	cal := Calendar{Name: fmt.Sprintf("%s-%d", printNo, session)}
+	for _, m := range meetings {
+		if containsBill(m.Bills, bill) {
+			cal.Events = append(cal.Events, CommitteeMeetingEvent(m))
+		}
+	}
+	first, last := civil.DateOf(from), civil.DateOf(to)
+	for year := from.Year(); year <= to.Year(); year++ {
+		days, err := h.getSessionDays(r.Context(), year)
+		if err != nil {
+			h.error(w, r, err)
+			return
+		}
+		for _, d := range days {
+			if d.Date.Before(first) || d.Date.After(last) {
+				continue
+			}
+			if containsBill(d.Bills, bill) {
+				cal.Events = append(cal.Events, SessionDayEvent(d))
+			}
+		}
+	}
+	h.write(w, r, cal)
+}
+
+// getSessionDays returns the session days for a year from the cache or Source
+func (h *Handler) getSessionDays(ctx context.Context, year int) ([]nysenateapi.SessionDay, error) {
+	h.mutex.Lock()
+	c, ok := h.sessionDays[year]
+	h.mutex.Unlock()
+	if ok && h.now().Sub(c.fetched) < h.SessionDaysTTL {
+		return c.days, nil
+	}
+	days, err := h.Source.SessionDays(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	h.mutex.Lock()
+	h.sessionDays[year] = cachedSessionDays{days: days, fetched: h.now()}
+	h.mutex.Unlock()
+	return days, nil
+}
+
+func containsBill(bills []nysenateapi.BillReference, b nysenateapi.BillReference) bool {
+	for _, bb := range bills {
+		if bb == b {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) logger() *slog.Logger {
+	if h.Logger == nil {
+		return slog.Default()
+	}
+	return h.Logger
+}
+
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, err error) {
+	h.logger().ErrorContext(r.Context(), "ics feed", "error", err, "path", r.URL.Path)
+	http.Error(w, "upstream error", http.StatusBadGateway)
+}
+
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, cal Calendar) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if _, err := cal.WriteTo(w); err != nil {
+		h.logger().WarnContext(r.Context(), "writing ics feed", "error", err)
+	}
+}
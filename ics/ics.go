@@ -0,0 +1,232 @@
+// Package ics renders committee meetings and session days as RFC 5545 iCalendar feeds.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi"
+)
+
+const uidDomain = "legislation.nysenate.gov"
+
+// DefaultMeetingDuration is used for the end time of committee meetings which have no published end time
+var DefaultMeetingDuration = time.Hour
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	// Start is a local time in America/New_York
+	Start    civil.DateTime
+	Duration time.Duration
+	// AllDay events use Start.Date and ignore Duration
+	AllDay bool
+}
+
+type Calendar struct {
+	Name   string
+	Events []Event
+	// Stamp is used for DTSTAMP; it defaults to the current time
+	Stamp time.Time
+}
+
+var slugReplacer = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(s string) string {
+	return strings.Trim(slugReplacer.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// CommitteeMeetingEvent converts a committee meeting into an Event.
+//
+// The UID is stable across agenda addenda for the same meeting. Assembly meetings and hearings don't have
+// an agenda number so they are identified by committee and week; a meeting rescheduled within the week keeps
+// its UID. This assumes a committee meets at most once a week.
+func CommitteeMeetingEvent(m nysenateapi.CommitteeMeeting) Event {
+	e := Event{
+		UID:      fmt.Sprintf("agenda-%d-%d-%s-%s@%s", m.AgendaYear, m.AgendaNumber, slug(m.Chamber), slug(m.Committee), uidDomain),
+		Summary:  strings.TrimSpace(fmt.Sprintf("%s %s Committee Meeting", titleCase(m.Chamber), m.Committee)),
+		Location: m.Location,
+		Start:    m.Time,
+		Duration: DefaultMeetingDuration,
+		AllDay:   m.TimeUnknown,
+	}
+	if m.AgendaNumber == 0 {
+		kind, name := "meeting", m.Committee
+		if m.Hearing {
+			kind = "hearing"
+		}
+		if name == "" {
+			// hearings without committees are only identified by subject
+			name = m.Subject
+		}
+		year, week := m.Time.Date.In(time.UTC).ISOWeek()
+		e.UID = fmt.Sprintf("%s-%dw%02d-%s-%s@%s", kind, year, week, slug(m.Chamber), slug(name), uidDomain)
+	}
+	if m.Hearing {
+		e.Summary = strings.TrimSpace(fmt.Sprintf("%s Public Hearing: %s", titleCase(m.Chamber), m.Subject))
+	}
+	var desc []string
+	if m.Hearing && m.Committee != "" {
+		desc = append(desc, "Committees: "+m.Committee)
+	}
+	if m.Chair != "" {
+		desc = append(desc, "Chair: "+m.Chair)
+	}
+	if m.Notes != "" {
+		desc = append(desc, strings.TrimSpace(m.Notes))
+	}
+	if len(m.Bills) > 0 {
+		desc = append(desc, "Bills: "+billList(m.Bills))
+	}
+	e.Description = strings.Join(desc, "\n\n")
+	return e
+}
+
+// SessionDayEvent converts a session day into an all day Event.
+func SessionDayEvent(d nysenateapi.SessionDay) Event {
+	e := Event{
+		UID:     fmt.Sprintf("calendar-%d-%d@%s", d.Year, d.CalendarNumber, uidDomain),
+		Summary: fmt.Sprintf("Senate Session (Calendar %d)", d.CalendarNumber),
+		Start:   civil.DateTime{Date: d.Date},
+		AllDay:  true,
+	}
+	if len(d.Bills) > 0 {
+		e.Description = "Bills: " + billList(d.Bills)
+	}
+	return e
+}
+
+func billList(bills []nysenateapi.BillReference) string {
+	var s []string
+	for _, b := range bills {
+		s = append(s, fmt.Sprintf("%s-%d", b.PrintNo, b.Session))
+	}
+	return strings.Join(s, ", ")
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
+
+// vtimezone describes America/New_York using the post-2007 US DST rules
+const vtimezone = `BEGIN:VTIMEZONE
+TZID:America/New_York
+BEGIN:DAYLIGHT
+TZOFFSETFROM:-0500
+TZOFFSETTO:-0400
+TZNAME:EDT
+DTSTART:20070311T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:-0400
+TZOFFSETTO:-0500
+TZNAME:EST
+DTSTART:20071104T020000
+RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// WriteTo writes the calendar in iCalendar format
+func (c Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	stamp := c.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//jehiah//nysenateapi//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		cw.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	cw.line("X-WR-TIMEZONE:America/New_York")
+	for _, l := range strings.Split(vtimezone, "\n") {
+		cw.line(l)
+	}
+	for _, e := range c.Events {
+		cw.line("BEGIN:VEVENT")
+		cw.line("UID:" + e.UID)
+		cw.line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		if e.AllDay {
+			cw.line("DTSTART;VALUE=DATE:" + formatDate(e.Start.Date))
+			cw.line("DTEND;VALUE=DATE:" + formatDate(e.Start.Date.AddDays(1)))
+		} else {
+			cw.line("DTSTART;TZID=America/New_York:" + formatDateTime(e.Start))
+			if e.Duration > 0 {
+				end := civil.DateTimeOf(e.Start.In(time.UTC).Add(e.Duration))
+				cw.line("DTEND;TZID=America/New_York:" + formatDateTime(end))
+			}
+		}
+		cw.line("SUMMARY:" + escape(e.Summary))
+		if e.Location != "" {
+			cw.line("LOCATION:" + escape(e.Location))
+		}
+		if e.Description != "" {
+			cw.line("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.URL != "" {
+			cw.line("URL:" + e.URL)
+		}
+		cw.line("END:VEVENT")
+	}
+	cw.line("END:VCALENDAR")
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+func formatDate(d civil.Date) string {
+	return fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day)
+}
+
+func formatDateTime(d civil.DateTime) string {
+	return fmt.Sprintf("%sT%02d%02d%02d", formatDate(d.Date), d.Time.Hour, d.Time.Minute, d.Time.Second)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
+
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+// line writes a content line folded at 75 octets (RFC 5545 3.1)
+func (c *countWriter) line(s string) {
+	if c.err != nil {
+		return
+	}
+	var out strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			out.WriteString("\r\n ")
+			width = 1
+		}
+		out.WriteRune(r)
+		width += size
+	}
+	out.WriteString("\r\n")
+	n, err := c.w.WriteString(out.String())
+	c.n += int64(n)
+	c.err = err
+}
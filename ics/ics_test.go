@@ -0,0 +1,177 @@
+package ics
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/jehiah/nysenateapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMeeting = nysenateapi.CommitteeMeeting{
+	AgendaNumber: 3,
+	AgendaYear:   2024,
+	Chamber:      "SENATE",
+	Committee:    "Cities 1",
+	Chair:        "Robert Jackson",
+	Location:     "Room 124 CAP",
+	Time:         civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 23}, Time: civil.Time{Hour: 11}},
+	Bills: []nysenateapi.BillReference{
+		{PrintNo: "S1234", Session: 2023},
+		{PrintNo: "S5678", Session: 2023},
+	},
+}
+
+func TestCalendarWriteTo(t *testing.T) {
+	cal := Calendar{
+		Name:  "Cities 1 Committee",
+		Stamp: time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC),
+		Events: []Event{
+			CommitteeMeetingEvent(testMeeting),
+			SessionDayEvent(nysenateapi.SessionDay{Date: civil.Date{Year: 2024, Month: 1, Day: 24}, Year: 2024, CalendarNumber: 4}),
+		},
+	}
+	var buf bytes.Buffer
+	n, err := cal.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	out := buf.String()
+	t.Log(out)
+
+	for _, line := range []string{
+		"UID:agenda-2024-3-senate-cities-1@legislation.nysenate.gov",
+		"DTSTAMP:20240120T120000Z",
+		"DTSTART;TZID=America/New_York:20240123T110000",
+		"DTEND;TZID=America/New_York:20240123T120000",
+		"SUMMARY:Senate Cities 1 Committee Meeting",
+		`DESCRIPTION:Chair: Robert Jackson\n\nBills: S1234-2023\, S5678-2023`,
+		"UID:calendar-2024-4@legislation.nysenate.gov",
+		"DTSTART;VALUE=DATE:20240124",
+		"DTEND;VALUE=DATE:20240125",
+	} {
+		assert.Contains(t, out, line+"\r\n")
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+}
+
+func TestFolding(t *testing.T) {
+	var buf bytes.Buffer
+	Calendar{Events: []Event{{UID: "x", Summary: strings.Repeat("é", 50)}}}.WriteTo(&buf)
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	assert.Contains(t, buf.String(), "\r\n é")
+}
+
+type fakeSource struct {
+	meetings    []nysenateapi.CommitteeMeeting
+	days        []nysenateapi.SessionDay
+	dayRequests *int
+}
+
+func (f fakeSource) CommitteeMeetings(ctx context.Context, from, to time.Time) ([]nysenateapi.CommitteeMeeting, error) {
+	return f.meetings, nil
+}
+func (f fakeSource) SessionDays(ctx context.Context, year int) ([]nysenateapi.SessionDay, error) {
+	if f.dayRequests != nil {
+		*f.dayRequests++
+	}
+	if year != 2024 {
+		return nil, nil
+	}
+	return f.days, nil
+}
+
+func TestHandler(t *testing.T) {
+	other := testMeeting
+	other.Committee = "Finance"
+	other.Bills = nil
+	var dayRequests int
+	h := NewHandler(fakeSource{
+		meetings: []nysenateapi.CommitteeMeeting{testMeeting, other},
+		days: []nysenateapi.SessionDay{
+			{Date: civil.Date{Year: 2024, Month: 1, Day: 24}, Year: 2024, CalendarNumber: 4, Bills: []nysenateapi.BillReference{{PrintNo: "S1234", Session: 2023}}},
+			{Date: civil.Date{Year: 2024, Month: 1, Day: 25}, Year: 2024, CalendarNumber: 5},
+			// outside the feed window
+			{Date: civil.Date{Year: 2024, Month: 11, Day: 25}, Year: 2024, CalendarNumber: 60, Bills: []nysenateapi.BillReference{{PrintNo: "S1234", Session: 2023}}},
+		},
+		dayRequests: &dayRequests,
+	})
+	h.now = func() time.Time { return time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC) }
+
+	type testCase struct {
+		path   string
+		code   int
+		events int
+	}
+	for _, tc := range []testCase{
+		{"/committee/cities%201.ics", 200, 1},
+		{"/committee/Finance.ics", 200, 1},
+		{"/committee/Finance", 404, 0},
+		{"/bill/2023/s1234.ics", 200, 2},
+		{"/bill/2023/S5678.ics", 200, 1},
+		{"/bill/2023/S9999.ics", 200, 0},
+		{"/bill/x/S9999.ics", 404, 0},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+			assert.Equal(t, tc.code, w.Code)
+			if w.Code != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.events, strings.Count(w.Body.String(), "BEGIN:VEVENT"))
+		})
+	}
+	// session days are fetched once for each year in the window (2023 and 2024)
+	assert.Equal(t, 2, dayRequests)
+}
+
+func TestAssemblyHearingEvent(t *testing.T) {
+	e := CommitteeMeetingEvent(nysenateapi.CommitteeMeeting{
+		Chamber:   "ASSEMBLY",
+		Committee: "Ways and Means",
+		Hearing:   true,
+		Subject:   "Implementation of the enacted budget",
+		Time:      civil.DateTime{Date: civil.Date{Year: 2025, Month: 1, Day: 16}, Time: civil.Time{Hour: 13, Minute: 30}},
+	})
+	assert.Equal(t, "hearing-2025w03-assembly-ways-and-means@legislation.nysenate.gov", e.UID)
+	assert.Equal(t, "Assembly Public Hearing: Implementation of the enacted budget", e.Summary)
+	assert.Equal(t, "Committees: Ways and Means", e.Description)
+	assert.False(t, e.AllDay)
+
+	// meetings "Off the Floor" don't have a set time
+	e = CommitteeMeetingEvent(nysenateapi.CommitteeMeeting{
+		Chamber:     "ASSEMBLY",
+		Committee:   "Ways and Means",
+		Time:        civil.DateTime{Date: civil.Date{Year: 2024, Month: 5, Day: 15}},
+		TimeUnknown: true,
+	})
+	assert.True(t, e.AllDay)
+	assert.Equal(t, "meeting-2024w20-assembly-ways-and-means@legislation.nysenate.gov", e.UID)
+
+	// rescheduled within the week or with a new subject
+	e2 := CommitteeMeetingEvent(nysenateapi.CommitteeMeeting{
+		Chamber:   "ASSEMBLY",
+		Committee: "Ways and Means",
+		Subject:   "Updated",
+		Time:      civil.DateTime{Date: civil.Date{Year: 2024, Month: 5, Day: 16}, Time: civil.Time{Hour: 10}},
+	})
+	assert.Equal(t, e.UID, e2.UID)
+}
+
+func TestCommitteeMeetingEventAddendum(t *testing.T) {
+	m := testMeeting
+	m.Addendum = "A"
+	assert.Equal(t, CommitteeMeetingEvent(testMeeting).UID, CommitteeMeetingEvent(m).UID)
+}
@@ -0,0 +1,272 @@
+package nysenateapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"golang.org/x/net/html"
+)
+
+// LawTextKind identifies text in a bill as existing law, new matter (underscored in the print)
+// or matter to be omitted (in brackets or stricken in the print)
+type LawTextKind string
+
+const (
+	ExistingLaw LawTextKind = "existing"
+	AddedLaw    LawTextKind = "added"
+	RemovedLaw  LawTextKind = "removed"
+)
+
+type LawSegment struct {
+	Kind LawTextKind `json:"Kind"`
+	Text string      `json:"Text"`
+}
+
+// BillSection is a numbered section of a bill ("Section 1.", "§ 2.", ...)
+type BillSection struct {
+	Number   int          `json:"Number"`
+	Segments []LawSegment `json:"Segments,omitempty"`
+}
+
+func (s BillSection) text(skip LawTextKind) string {
+	var parts []string
+	for _, seg := range s.Segments {
+		if seg.Kind != skip {
+			parts = append(parts, seg.Text)
+		}
+	}
+	return joinText(parts)
+}
+
+// Before returns the text of the section as the law reads today
+func (s BillSection) Before() string { return s.text(AddedLaw) }
+
+// After returns the text of the section as the law would read if the bill is enacted
+func (s BillSection) After() string { return s.text(RemovedLaw) }
+
+// Added returns the new matter in the section
+func (s BillSection) Added() []string { return s.kind(AddedLaw) }
+
+// Removed returns the matter to be omitted in the section
+func (s BillSection) Removed() []string { return s.kind(RemovedLaw) }
+
+func (s BillSection) kind(k LawTextKind) []string {
+	var o []string
+	for _, seg := range s.Segments {
+		if seg.Kind == k {
+			o = append(o, seg.Text)
+		}
+	}
+	return o
+}
+
+// GetLawChanges returns the sections of an amendment version of a bill ("" is the original print)
+// split into existing, added and removed law
+func (a *API) GetLawChanges(ctx context.Context, session, printNo, version string) ([]BillSection, error) {
+	text, err := a.GetRawBillText(ctx, session, printNo, version, verboseapi.HTMLText)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLawChanges(strings.NewReader(text))
+}
+
+type markedRune struct {
+	r    rune
+	kind LawTextKind
+}
+
+// bill sections are numbered sequentially; sections of law quoted in the bill (i.e. "§ 19-190.") are not
+var billSectionPattern = regexp.MustCompile(`^(?:Section|§) (\d+)\.\s`)
+
+// ParseLawChanges parses the HTML (or template) format of a bill. Underlined text is new matter;
+// bracketed or stricken text is removed.
+func ParseLawChanges(r io.Reader) ([]BillSection, error) {
+	runes, err := markRunes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHyphenation(string(runeText(runes)))
+	var sections []BillSection
+	var current []markedRune
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Segments = lawSegments(current)
+		}
+		current = nil
+	}
+	for _, line := range splitMarkedLines(runes) {
+		s := string(runeText(line))
+		if pageHeaderPattern.MatchString(s) {
+			continue
+		}
+		m := lineNumberPattern.FindStringSubmatchIndex(s)
+		if m == nil || m[4] < 0 {
+			continue
+		}
+		// convert the byte offset of the line text to a rune offset
+		body := line[len([]rune(s[:m[4]])):]
+		text := strings.TrimSpace(string(runeText(body)))
+		if sm := billSectionPattern.FindStringSubmatch(text + " "); sm != nil {
+			n, _ := strconv.Atoi(sm[1])
+			if n == len(sections)+1 {
+				flush()
+				sections = append(sections, BillSection{Number: n})
+			}
+		}
+		if joinHyphenated(h, &current, body) {
+			continue
+		}
+		current = append(current, markedRune{r: ' ', kind: ExistingLaw})
+		current = append(current, body...)
+	}
+	flush()
+	return sections, nil
+}
+
+// joinHyphenated appends the next line to current when it continues a word broken at the end of the line
+func joinHyphenated(h hyphenation, current *[]markedRune, body []markedRune) bool {
+	c := *current
+	for len(c) > 0 && unicode.IsSpace(c[len(c)-1].r) {
+		c = c[:len(c)-1]
+	}
+	for len(body) > 0 && unicode.IsSpace(body[0].r) {
+		body = body[1:]
+	}
+	head := strings.Fields(string(runeText(c)))
+	tail := strings.Fields(string(runeText(body)))
+	if len(head) == 0 || len(tail) == 0 || !h.breaks(head[len(head)-1], tail[0]) {
+		return false
+	}
+	if !h.compound(head[len(head)-1], tail[0]) {
+		c = c[:len(c)-1]
+	}
+	*current = append(c, body...)
+	return true
+}
+
+func markRunes(r io.Reader) ([]markedRune, error) {
+	var out []markedRune
+	var underline, strike int
+	var bracket bool
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		token := z.Token()
+		switch tt {
+		case html.ErrorToken:
+			err := z.Err()
+			if err == io.EOF {
+				err = nil
+			}
+			return out, err
+		case html.TextToken:
+			for _, c := range token.Data {
+				kind := ExistingLaw
+				switch {
+				case underline > 0:
+					kind = AddedLaw
+				case strike > 0:
+					kind = RemovedLaw
+				case c == '[':
+					bracket = true
+					continue
+				case c == ']' && bracket:
+					bracket = false
+					continue
+				case bracket && c != '\n':
+					kind = RemovedLaw
+				}
+				out = append(out, markedRune{r: c, kind: kind})
+			}
+		case html.StartTagToken:
+			switch token.Data {
+			case "u", "ins":
+				underline++
+			case "s", "strike", "del":
+				strike++
+			case "br", "p":
+				out = append(out, markedRune{r: '\n', kind: ExistingLaw})
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "u", "ins":
+				underline = max(0, underline-1)
+			case "s", "strike", "del":
+				strike = max(0, strike-1)
+			}
+		case html.SelfClosingTagToken:
+			if token.Data == "br" {
+				out = append(out, markedRune{r: '\n', kind: ExistingLaw})
+			}
+		}
+	}
+}
+
+func splitMarkedLines(runes []markedRune) [][]markedRune {
+	var lines [][]markedRune
+	start := 0
+	for i, m := range runes {
+		if m.r == '\n' {
+			lines = append(lines, runes[start:i])
+			start = i + 1
+		}
+	}
+	return append(lines, runes[start:])
+}
+
+func runeText(runes []markedRune) []rune {
+	o := make([]rune, len(runes))
+	for i, m := range runes {
+		o[i] = m.r
+	}
+	return o
+}
+
+// lawSegments groups runes by kind collapsing whitespace
+func lawSegments(runes []markedRune) []LawSegment {
+	var segments []LawSegment
+	var text []rune
+	kind := ExistingLaw
+	emit := func() {
+		t := strings.Join(strings.Fields(string(text)), " ")
+		if t != "" {
+			if n := len(segments); n > 0 && segments[n-1].Kind == kind {
+				segments[n-1].Text = joinText([]string{segments[n-1].Text, t})
+			} else {
+				segments = append(segments, LawSegment{Kind: kind, Text: t})
+			}
+		}
+		text = nil
+	}
+	for _, m := range runes {
+		if m.kind != kind {
+			// whitespace doesn't change the kind of a run
+			if m.r == ' ' || m.r == '\t' {
+				text = append(text, m.r)
+				continue
+			}
+			emit()
+			kind = m.kind
+		}
+		text = append(text, m.r)
+	}
+	emit()
+	return segments
+}
+
+func (s LawSegment) String() string {
+	switch s.Kind {
+	case AddedLaw:
+		return fmt.Sprintf("{+%s+}", s.Text)
+	case RemovedLaw:
+		return fmt.Sprintf("[-%s-]", s.Text)
+	}
+	return s.Text
+}
@@ -0,0 +1,68 @@
+package nysenateapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBillHTML = `<pre>
+                           S T A T E   O F   N E W   Y O R K
+       ________________________________________________________________________
+
+                                          2304
+
+          The  People of the State of New York, represented in Senate and Assem-
+        bly, do enact as follows:
+
+     1    Section 1. Section 19-190 of the administrative code of the city of
+     2  New York is amended to read as follows:
+     3    &sect; 19-190. Right of way. a. Except as provided in subdivision b of this
+     4  section, any driver of a motor vehicle who fails to yield to a [pedes-
+     5  trian] <u>pedestrian, person riding a bicycle</u> or person with a disability
+     6  shall be guilty of a traffic infraction.
+     7    &sect; 2. This act shall take effect [immediately] <U>on the ninetieth day
+     8  after it shall have become a law</U>.
+
+        S. 2304                             2
+
+     1    &sect; 3. Severability. <s>If any clause</s> <u>If any provision</u> is invalid.
+</pre>`
+
+func TestParseLawChanges(t *testing.T) {
+	sections, err := ParseLawChanges(strings.NewReader(sampleBillHTML))
+	require.NoError(t, err)
+	require.Len(t, sections, 3)
+
+	s := sections[0]
+	assert.Equal(t, 1, s.Number)
+	assert.Equal(t, []string{"pedestrian, person riding a bicycle"}, s.Added())
+	assert.Equal(t, []string{"pedestrian"}, s.Removed())
+	assert.Equal(t, "Section 1. Section 19-190 of the administrative code of the city of New York is amended to read as follows: § 19-190. Right of way. a. Except as provided in subdivision b of this section, any driver of a motor vehicle who fails to yield to a pedestrian, person riding a bicycle or person with a disability shall be guilty of a traffic infraction.", s.After())
+	assert.Contains(t, s.Before(), "fails to yield to a pedestrian or person with a disability")
+
+	s = sections[1]
+	assert.Equal(t, 2, s.Number)
+	assert.Equal(t, []LawSegment{
+		{ExistingLaw, "§ 2. This act shall take effect"},
+		{RemovedLaw, "immediately"},
+		{AddedLaw, "on the ninetieth day after it shall have become a law"},
+		{ExistingLaw, "."},
+	}, s.Segments)
+	assert.Equal(t, "§ 2. This act shall take effect on the ninetieth day after it shall have become a law.", s.After())
+	assert.Equal(t, "§ 2. This act shall take effect immediately.", s.Before())
+
+	s = sections[2]
+	assert.Equal(t, 3, s.Number)
+	assert.Equal(t, "§ 3. Severability. [-If any clause-] {+If any provision+} is invalid.", joinSegments(s.Segments))
+}
+
+func joinSegments(s []LawSegment) string {
+	var o []string
+	for _, seg := range s {
+		o = append(o, seg.String())
+	}
+	return strings.Join(o, " ")
+}
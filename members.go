@@ -0,0 +1,174 @@
+package nysenateapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+)
+
+// Defaults for the member cache
+const (
+	DefaultMemberTTL          = 24 * time.Hour
+	DefaultMemberErrorBackoff = time.Minute
+)
+
+type memberCacheKey struct {
+	session int
+	chamber verboseapi.Chamber
+}
+
+type memberCacheEntry struct {
+	// ready is closed when the fetch completes
+	ready   chan struct{}
+	members []verboseapi.MemberEntry
+	err     error
+	fetched time.Time
+}
+
+func (e *memberCacheEntry) done() bool {
+	select {
+	case <-e.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// memberCache caches members for each session and chamber. Concurrent lookups for the same session
+// share a single request; lookups for other sessions are not blocked by it. Failed lookups are cached
+// for errorBackoff before they are retried.
+type memberCache struct {
+	fetch        func(ctx context.Context, session string, c verboseapi.Chamber) ([]verboseapi.MemberEntry, error)
+	ttl          time.Duration
+	errorBackoff time.Duration
+	now          func() time.Time
+
+	mutex   sync.Mutex
+	entries map[memberCacheKey]*memberCacheEntry
+	// refreshing are fetches that replace a cached entry only when they succeed
+	refreshing map[memberCacheKey]*memberCacheEntry
+}
+
+func newMemberCache(api *verboseapi.NYSenateAPI) *memberCache {
+	return &memberCache{
+		fetch:        api.GetMembers,
+		ttl:          DefaultMemberTTL,
+		errorBackoff: DefaultMemberErrorBackoff,
+		now:          time.Now,
+		entries:      make(map[memberCacheKey]*memberCacheEntry),
+	}
+}
+
+// fresh reports if a completed entry can be used
+func (c *memberCache) fresh(e *memberCacheEntry) bool {
+	age := c.now().Sub(e.fetched)
+	if e.err != nil {
+		return age < c.errorBackoff
+	}
+	return age < c.ttl
+}
+
+// start begins fetching members; the caller must hold c.mutex. Unless keep is set the entry replaces the
+// cached entry immediately; with keep it replaces the cached entry only if the fetch succeeds.
+func (c *memberCache) start(ctx context.Context, k memberCacheKey, keep bool) *memberCacheEntry {
+	e := &memberCacheEntry{ready: make(chan struct{})}
+	if keep {
+		if c.refreshing == nil {
+			c.refreshing = make(map[memberCacheKey]*memberCacheEntry)
+		}
+		c.refreshing[k] = e
+	} else {
+		c.entries[k] = e
+	}
+	// the fetch is shared by all waiters so it isn't cancelled with the first caller
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		members, err := c.fetch(ctx, strconv.Itoa(k.session), k.chamber)
+		c.mutex.Lock()
+		e.members, e.err, e.fetched = members, err, c.now()
+		if keep {
+			delete(c.refreshing, k)
+			if err == nil {
+				c.entries[k] = e
+			}
+		}
+		c.mutex.Unlock()
+		close(e.ready)
+	}()
+	return e
+}
+
+func (c *memberCache) wait(ctx context.Context, e *memberCacheEntry) ([]verboseapi.MemberEntry, error) {
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return e.members, e.err
+}
+
+func (c *memberCache) get(ctx context.Context, session int, chamber verboseapi.Chamber) ([]verboseapi.MemberEntry, error) {
+	k := memberCacheKey{session, chamber}
+	c.mutex.Lock()
+	e, ok := c.entries[k]
+	if !ok || (e.done() && !c.fresh(e)) {
+		e = c.start(ctx, k, false)
+	}
+	c.mutex.Unlock()
+	return c.wait(ctx, e)
+}
+
+// refresh fetches members again unless a fetch is already in progress. Previously fetched members
+// are used until the refresh succeeds and are kept if it fails.
+func (c *memberCache) refresh(ctx context.Context, session int, chamber verboseapi.Chamber) error {
+	k := memberCacheKey{session, chamber}
+	c.mutex.Lock()
+	e, ok := c.entries[k]
+	switch {
+	case ok && !e.done():
+	case ok && e.err == nil:
+		if r, ok := c.refreshing[k]; ok {
+			e = r
+		} else {
+			e = c.start(ctx, k, true)
+		}
+	default:
+		e = c.start(ctx, k, false)
+	}
+	c.mutex.Unlock()
+	_, err := c.wait(ctx, e)
+	return err
+}
+
+// Members returns the members of a chamber for a session. Results are cached for DefaultMemberTTL.
+func (a *API) Members(ctx context.Context, session int, chamber verboseapi.Chamber) ([]verboseapi.MemberEntry, error) {
+	return a.members.get(ctx, session, chamber)
+}
+
+// RefreshMembers replaces the cached members of a chamber for a session
+func (a *API) RefreshMembers(ctx context.Context, session int, chamber verboseapi.Chamber) error {
+	return a.members.refresh(ctx, session, chamber)
+}
+
+// PreloadMembers concurrently loads Senate and Assembly members for each session into the cache
+func (a *API) PreloadMembers(ctx context.Context, sessions ...int) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(sessions)*2)
+	for i, session := range sessions {
+		for j, chamber := range []verboseapi.Chamber{verboseapi.SenateChamber, verboseapi.AssemblyChamber} {
+			wg.Add(1)
+			go func(n int, session int, chamber verboseapi.Chamber) {
+				defer wg.Done()
+				_, errs[n] = a.members.get(ctx, session, chamber)
+			}(i*2+j, session, chamber)
+		}
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,196 @@
+package nysenateapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMembers struct {
+	calls   int32
+	err     error
+	errs    map[string]error         // errors for "session-chamber"
+	release map[string]chan struct{} // blocks fetches for "session-chamber" until closed
+}
+
+func (f *fakeMembers) fetch(ctx context.Context, session string, c verboseapi.Chamber) ([]verboseapi.MemberEntry, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if ch, ok := f.release[session+"-"+string(c)]; ok {
+		<-ch
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	if err := f.errs[session+"-"+string(c)]; err != nil {
+		return nil, err
+	}
+	return []verboseapi.MemberEntry{{MemberID: 1, ShortName: session + "-" + string(c)}}, nil
+}
+
+func testMemberCache(f *fakeMembers, now *time.Time) *memberCache {
+	return &memberCache{
+		fetch:        f.fetch,
+		ttl:          time.Hour,
+		errorBackoff: time.Minute,
+		now:          func() time.Time { return *now },
+		entries:      make(map[memberCacheKey]*memberCacheEntry),
+	}
+}
+
+func TestMemberCacheSingleFlight(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{release: map[string]chan struct{}{"2023-assembly": make(chan struct{})}}
+	c := testMemberCache(f, &now)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := c.get(ctx, 2023, verboseapi.AssemblyChamber)
+			assert.NoError(t, err)
+			assert.Len(t, m, 1)
+		}()
+	}
+
+	// another session isn't blocked by the slow request
+	m, err := c.get(ctx, 2021, verboseapi.AssemblyChamber)
+	require.NoError(t, err)
+	assert.Equal(t, "2021-assembly", m[0].ShortName)
+	// nor is the other chamber
+	m, err = c.get(ctx, 2023, verboseapi.SenateChamber)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-senate", m[0].ShortName)
+
+	close(f.release["2023-assembly"])
+	wg.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&f.calls))
+
+	// cached until the ttl expires
+	_, err = c.get(ctx, 2023, verboseapi.AssemblyChamber)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&f.calls))
+	now = now.Add(2 * time.Hour)
+	_, err = c.get(ctx, 2023, verboseapi.AssemblyChamber)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&f.calls))
+}
+
+func TestMemberCacheErrorBackoff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{err: errors.New("unavailable")}
+	c := testMemberCache(f, &now)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.get(ctx, 2023, verboseapi.AssemblyChamber)
+		require.Error(t, err)
+	}
+	assert.Equal(t, int32(1), f.calls)
+
+	now = now.Add(2 * time.Minute)
+	f.err = nil
+	m, err := c.get(ctx, 2023, verboseapi.AssemblyChamber)
+	require.NoError(t, err)
+	assert.Len(t, m, 1)
+	assert.Equal(t, int32(2), f.calls)
+}
+
+func TestMemberCacheRefresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{}
+	c := testMemberCache(f, &now)
+	ctx := context.Background()
+
+	require.NoError(t, c.refresh(ctx, 2023, verboseapi.SenateChamber))
+	_, err := c.get(ctx, 2023, verboseapi.SenateChamber)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), f.calls)
+	require.NoError(t, c.refresh(ctx, 2023, verboseapi.SenateChamber))
+	assert.Equal(t, int32(2), f.calls)
+
+	// a failed refresh keeps the cached members
+	f.err = errors.New("unavailable")
+	assert.Error(t, c.refresh(ctx, 2023, verboseapi.SenateChamber))
+	m, err := c.get(ctx, 2023, verboseapi.SenateChamber)
+	require.NoError(t, err)
+	assert.Len(t, m, 1)
+	assert.Equal(t, int32(3), f.calls)
+}
+
+func TestMemberCacheRefreshInProgress(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{}
+	c := testMemberCache(f, &now)
+	ctx := context.Background()
+	_, err := c.get(ctx, 2023, verboseapi.SenateChamber)
+	require.NoError(t, err)
+
+	// cached members are returned while a refresh is in progress
+	f.release = map[string]chan struct{}{"2023-senate": make(chan struct{})}
+	done := make(chan error)
+	go func() { done <- c.refresh(ctx, 2023, verboseapi.SenateChamber) }()
+	for atomic.LoadInt32(&f.calls) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	m, err := c.get(ctx, 2023, verboseapi.SenateChamber)
+	require.NoError(t, err)
+	assert.Len(t, m, 1)
+	close(f.release["2023-senate"])
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&f.calls))
+}
+
+func TestMemberCacheCancel(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{release: map[string]chan struct{}{"2023-assembly": make(chan struct{})}}
+	c := testMemberCache(f, &now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.get(ctx, 2023, verboseapi.AssemblyChamber)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// the shared fetch continues for other callers
+	close(f.release["2023-assembly"])
+	m, err := c.get(context.Background(), 2023, verboseapi.AssemblyChamber)
+	require.NoError(t, err)
+	assert.Len(t, m, 1)
+	assert.Equal(t, int32(1), f.calls)
+}
+
+func TestPreloadMembers(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{}
+	a := &API{members: testMemberCache(f, &now)}
+	require.NoError(t, a.PreloadMembers(context.Background(), 2021, 2023))
+	assert.Equal(t, int32(4), f.calls)
+	m, err := a.Members(context.Background(), 2021, verboseapi.SenateChamber)
+	require.NoError(t, err)
+	assert.Equal(t, "2021-senate", m[0].ShortName)
+	assert.Equal(t, int32(4), f.calls)
+}
+
+func TestAssemblyResolverPreviousSessionError(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeMembers{errs: map[string]error{"2021-assembly": errors.New("unavailable")}}
+	a := &API{members: testMemberCache(f, &now)}
+
+	r, err := a.getAssemblyResolver(context.Background(), 2023)
+	require.NoError(t, err)
+	m := r.Resolve("2023-assembly")
+	assert.Equal(t, 1, m.Member.MemberID)
+
+	// current session members are required
+	f.errs["2025-assembly"] = errors.New("unavailable")
+	_, err = a.getAssemblyResolver(context.Background(), 2025)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package nysenateapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SponsorMemo is a sponsor's memorandum in support of a bill split into its standard sections
+type SponsorMemo struct {
+	BillNumber         string        `json:"BillNumber,omitempty"`
+	Sponsor            string        `json:"Sponsor,omitempty"`
+	Title              string        `json:"Title,omitempty"`
+	Purpose            string        `json:"Purpose,omitempty"`
+	Summary            string        `json:"Summary,omitempty"`
+	Justification      string        `json:"Justification,omitempty"`
+	LegislativeHistory string        `json:"LegislativeHistory,omitempty"`
+	FiscalImplications string        `json:"FiscalImplications,omitempty"`
+	EffectiveDate      string        `json:"EffectiveDate,omitempty"`
+	Other              []MemoSection `json:"Other,omitempty"`
+	Text               string        `json:"Text,omitempty"`
+}
+
+// MemoSection is a memo section without a standard heading
+type MemoSection struct {
+	Heading string `json:"Heading"`
+	Text    string `json:"Text"`
+}
+
+var (
+	memoHeadingPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z ,()/&'-]{2,80}?)\s*:\s*(.*)$`)
+	memoSpaces         = regexp.MustCompile(`[ \t\x{a0}]+`)
+)
+
+// memoField returns the SponsorMemo field for a known heading
+func (m *SponsorMemo) memoField(heading string) *string {
+	h := strings.Join(strings.Fields(strings.ToUpper(heading)), " ")
+	switch {
+	case h == "BILL NUMBER" || h == "BILL NO":
+		return &m.BillNumber
+	case h == "SPONSOR" || h == "SPONSOR(S)" || h == "SPONSORS":
+		return &m.Sponsor
+	case strings.HasPrefix(h, "TITLE"):
+		return &m.Title
+	case strings.HasPrefix(h, "PURPOSE"):
+		return &m.Purpose
+	case strings.HasPrefix(h, "SUMMARY"):
+		return &m.Summary
+	case strings.HasPrefix(h, "JUSTIFICATION") || h == "STATEMENT IN SUPPORT":
+		return &m.Justification
+	case strings.Contains(h, "LEGISLATIVE HISTORY"):
+		return &m.LegislativeHistory
+	case strings.HasPrefix(h, "FISCAL") || strings.HasPrefix(h, "BUDGET IMPLICATIONS"):
+		return &m.FiscalImplications
+	case strings.HasPrefix(h, "EFFECTIVE DATE"):
+		return &m.EffectiveDate
+	}
+	return nil
+}
+
+// ParseMemo splits a sponsor memo into sections. Headings are matched case insensitively with or
+// without text on the same line; unrecognized upper case headings are kept in Other.
+func ParseMemo(s string) *SponsorMemo {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	m := &SponsorMemo{Text: s}
+	var field *string
+	var other *MemoSection
+	var lines []string
+	flush := func() {
+		text := memoParagraphs(lines)
+		switch {
+		case field != nil:
+			if *field != "" && text != "" {
+				*field += "\n\n"
+			}
+			*field += text
+		case other != nil:
+			other.Text = text
+			m.Other = append(m.Other, *other)
+		}
+		field, other, lines = nil, nil, nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if h := memoHeadingPattern.FindStringSubmatch(line); h != nil {
+			heading := strings.TrimSpace(h[1])
+			if f := m.memoField(heading); f != nil {
+				flush()
+				field = f
+				lines = append(lines, h[2])
+				continue
+			}
+			if heading == strings.ToUpper(heading) {
+				flush()
+				other = &MemoSection{Heading: heading}
+				lines = append(lines, h[2])
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return m
+}
+
+// memoParagraphs joins lines into paragraphs separated by a blank line
+func memoParagraphs(lines []string) string {
+	var paragraphs []string
+	var p []string
+	for _, l := range lines {
+		l = strings.TrimSpace(memoSpaces.ReplaceAllString(l, " "))
+		if l == "" {
+			if len(p) > 0 {
+				paragraphs = append(paragraphs, strings.Join(p, "\n"))
+				p = nil
+			}
+			continue
+		}
+		p = append(p, l)
+	}
+	if len(p) > 0 {
+		paragraphs = append(paragraphs, strings.Join(p, "\n"))
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
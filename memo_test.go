@@ -0,0 +1,41 @@
+package nysenateapi
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestParseMemo(t *testing.T) {
+	files, err := filepath.Glob("testdata/memos/*.txt")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			in, err := os.ReadFile(f)
+			require.NoError(t, err)
+			m := ParseMemo(string(in))
+			require.NotNil(t, m)
+			m.Text = ""
+			got, err := json.MarshalIndent(m, "", "  ")
+			require.NoError(t, err)
+
+			golden := strings.TrimSuffix(f, ".txt") + ".golden.json"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, append(got, '\n'), 0644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+	assert.Nil(t, ParseMemo(" \n"))
+}
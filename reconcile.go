@@ -0,0 +1,219 @@
+package nysenateapi
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/civil"
+)
+
+// Vote sources
+const (
+	SourceOpenLegislation = "openlegislation"
+	SourceAssembly        = "nyassembly.gov"
+)
+
+// VoteConflict is a member whose vote differs between two sources for the same vote
+type VoteConflict struct {
+	Chamber   string     `json:"Chamber"`
+	Date      civil.Date `json:"Date"`
+	Committee string     `json:"Committee,omitempty"`
+	VoteType  VoteKind   `json:"VoteType"`
+	// Preferred is the entry kept from the authoritative source
+	Preferred       VoteEntry `json:"Preferred"`
+	PreferredSource string    `json:"PreferredSource"`
+	Other           VoteEntry `json:"Other"`
+	OtherSource     string    `json:"OtherSource"`
+}
+
+// memberKey identifies a member within a single vote
+func memberKey(id int, shortName string) string {
+	if id > 0 {
+		return fmt.Sprintf("%d", id)
+	}
+	return strings.ToUpper(shortName)
+}
+
+func voteChamber(v Vote) string {
+	if c := v.chamber(); c != "" {
+		return c
+	}
+	// votes without a source or chamber are assumed to be from OpenLegislation
+	return "SENATE"
+}
+
+// authoritative reports if source is the preferred source for votes in a chamber
+func authoritative(chamber, source string) bool {
+	if chamber == "ASSEMBLY" {
+		return source == SourceAssembly
+	}
+	return source == SourceOpenLegislation || source == ""
+}
+
+// ReconcileVotes merges votes from different sources that have the same chamber, date, amendment version,
+// committee and vote type. A vote without a version (i.e. from nyassembly.gov) matches a single vote with
+// a version and the same chamber, date, committee and type. Votes from the same source are separate roll calls and are never merged; when a
+// source has several matching votes they are paired with the other source's votes in order. Member entries
+// are de-duplicated by member ID (or short name when there is no ID) with entries from the authoritative
+// source for the chamber preferred. Members whose vote differs between sources are returned as conflicts.
+func ReconcileVotes(votes []Vote) ([]Vote, []VoteConflict) {
+	type voteKey struct {
+		chamber   string
+		date      civil.Date
+		version   string
+		committee string
+		voteType  VoteKind
+	}
+	type voteGroup struct {
+		key   voteKey
+		votes []Vote
+	}
+	var groups []*voteGroup
+	hasSource := func(g *voteGroup, source string) bool {
+		for _, v := range g.votes {
+			if v.Source == source {
+				return true
+			}
+		}
+		return false
+	}
+	sharesSource := func(a, b *voteGroup) bool {
+		for _, v := range a.votes {
+			if hasSource(b, v.Source) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range votes {
+		k := voteKey{voteChamber(v), v.Date, strings.ToUpper(v.Version), strings.ToUpper(v.Committee), v.VoteType}
+		var group *voteGroup
+		for _, g := range groups {
+			if g.key == k && !hasSource(g, v.Source) {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &voteGroup{key: k}
+			groups = append(groups, group)
+		}
+		group.votes = append(group.votes, v)
+	}
+
+	// nyassembly.gov votes don't have a version; a group without a version is merged into the
+	// only group of votes from other sources with a version and otherwise the same key
+	var merged []*voteGroup
+	for _, g := range groups {
+		if g.key.version != "" {
+			merged = append(merged, g)
+			continue
+		}
+		var match *voteGroup
+		for _, c := range groups {
+			k := c.key
+			k.version = ""
+			if c.key.version == "" || k != g.key || sharesSource(g, c) {
+				continue
+			}
+			if match != nil {
+				// ambiguous
+				match = nil
+				break
+			}
+			match = c
+		}
+		if match == nil {
+			merged = append(merged, g)
+			continue
+		}
+		match.votes = append(match.votes, g.votes...)
+	}
+	groups = merged
+
+	var out []Vote
+	var conflicts []VoteConflict
+	for _, g := range groups {
+		k, group := g.key, g.votes
+		// authoritative votes first, otherwise in the original order
+		var ordered []Vote
+		for _, v := range group {
+			if authoritative(k.chamber, v.Source) {
+				ordered = append(ordered, v)
+			}
+		}
+		for _, v := range group {
+			if !authoritative(k.chamber, v.Source) {
+				ordered = append(ordered, v)
+			}
+		}
+
+		merged := ordered[0]
+		merged.Votes = nil
+		merged.Warnings = nil
+		var sources []string // source of each entry in merged.Votes
+		byID := make(map[int]int)
+		byShort := make(map[string]int)
+		find := func(e VoteEntry) (int, bool) {
+			if i, ok := byID[e.ID]; ok && e.ID > 0 {
+				return i, true
+			}
+			i, ok := byShort[strings.ToUpper(e.Short)]
+			if ok && e.Short != "" && (e.ID == 0 || merged.Votes[i].ID == 0) {
+				return i, true
+			}
+			return 0, false
+		}
+		for _, v := range ordered {
+			for _, e := range v.Votes {
+				i, ok := find(e)
+				if !ok {
+					i = len(merged.Votes)
+					merged.Votes = append(merged.Votes, e)
+					sources = append(sources, v.Source)
+				}
+				existing := merged.Votes[i]
+				if existing.ID == 0 && e.ID != 0 {
+					merged.Votes[i].ID = e.ID
+				}
+				if existing.Name == "" {
+					merged.Votes[i].Name = e.Name
+				}
+				if existing.Short == "" {
+					merged.Votes[i].Short = e.Short
+				}
+				if merged.Votes[i].ID > 0 {
+					byID[merged.Votes[i].ID] = i
+				}
+				if merged.Votes[i].Short != "" {
+					byShort[strings.ToUpper(merged.Votes[i].Short)] = i
+				}
+				if ok && existing.Vote != e.Vote && sources[i] != v.Source {
+					conflicts = append(conflicts, VoteConflict{
+						Chamber:         k.chamber,
+						Date:            k.date,
+						Committee:       merged.Committee,
+						VoteType:        k.voteType,
+						Preferred:       existing,
+						PreferredSource: sources[i],
+						Other:           e,
+						OtherSource:     v.Source,
+					})
+				}
+			}
+			if merged.Version == "" && v.Version != "" {
+				merged.Version = v.Version
+				merged.Active = v.Active
+			}
+			if merged.Chair == "" {
+				merged.Chair = v.Chair
+			}
+			if merged.Outcome == "" {
+				merged.Outcome = v.Outcome
+			}
+			merged.Warnings = append(merged.Warnings, v.Warnings...)
+		}
+		out = append(out, merged)
+	}
+	return out, conflicts
+}
@@ -0,0 +1,132 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"github.com/jehiah/nysenateapi/verboseapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileVotes(t *testing.T) {
+	d := date(2023, 6, 7)
+	votes := []Vote{
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceOpenLegislation, Votes: []VoteEntry{
+			{ID: 1, Short: "SMITH", Name: "Jane Smith", Vote: VoteAye},
+			{ID: 2, Short: "JONES", Vote: VoteNay},
+		}},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly, Votes: []VoteEntry{
+			{ID: 1, Short: "SMITH", Vote: VoteAye},
+			{ID: 2, Short: "JONES", Vote: VoteAye},
+			{Short: "DOE", Vote: VoteExcused},
+		}},
+		{VoteType: CommitteeVote, Date: d, Chamber: "ASSEMBLY", Committee: "Codes", Source: SourceAssembly, Votes: []VoteEntry{
+			{ID: 1, Short: "SMITH", Vote: VoteAye},
+		}},
+		{VoteType: FloorVote, Date: d, Source: SourceOpenLegislation, Votes: []VoteEntry{
+			{ID: 10, Short: "GOUNARDES", Vote: VoteAye},
+			{ID: 10, Short: "GOUNARDES", Vote: VoteAye},
+		}},
+	}
+	out, conflicts := ReconcileVotes(votes)
+	require.Len(t, out, 3)
+
+	assert.Equal(t, SourceAssembly, out[0].Source)
+	assert.Equal(t, []VoteEntry{
+		{ID: 1, Short: "SMITH", Name: "Jane Smith", Vote: VoteAye},
+		{ID: 2, Short: "JONES", Vote: VoteAye},
+		{Short: "DOE", Vote: VoteExcused},
+	}, out[0].Votes)
+	assert.Len(t, out[1].Votes, 1)
+	assert.Len(t, out[2].Votes, 1)
+
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, VoteAye, conflicts[0].Preferred.Vote)
+	assert.Equal(t, SourceAssembly, conflicts[0].PreferredSource)
+	assert.Equal(t, VoteNay, conflicts[0].Other.Vote)
+	assert.Equal(t, SourceOpenLegislation, conflicts[0].OtherSource)
+}
+
+func TestReconcileVotesSameSource(t *testing.T) {
+	d := date(2023, 6, 7)
+	out, conflicts := ReconcileVotes([]Vote{
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly, Votes: []VoteEntry{{ID: 1, Short: "SMITH", Vote: VoteAye}}},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly, Votes: []VoteEntry{
+			{ID: 1, Short: "SMITH", Vote: VoteNay},
+			{ID: 2, Short: "JONES", Vote: VoteAye},
+		}},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceOpenLegislation, Votes: []VoteEntry{{ID: 1, Short: "SMITH", Vote: VoteAye}}},
+	})
+	require.Len(t, out, 2)
+	// the first roll call is merged with the OpenLegislation vote; the second is kept
+	assert.Equal(t, []VoteEntry{{ID: 1, Short: "SMITH", Vote: VoteAye}}, out[0].Votes)
+	assert.Equal(t, []VoteEntry{
+		{ID: 1, Short: "SMITH", Vote: VoteNay},
+		{ID: 2, Short: "JONES", Vote: VoteAye},
+	}, out[1].Votes)
+	assert.Empty(t, conflicts)
+}
+
+func TestReconcileVotesMissingVersion(t *testing.T) {
+	d := date(2023, 6, 7)
+	out, conflicts := ReconcileVotes([]Vote{
+		{VoteType: FloorVote, Date: d, Version: "A", Active: true, Chamber: "ASSEMBLY", Source: SourceOpenLegislation, Votes: []VoteEntry{{ID: 1, Short: "SMITH", Vote: VoteAye}}},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly, Votes: []VoteEntry{
+			{ID: 1, Short: "SMITH", Vote: VoteAye},
+			{ID: 2, Short: "JONES", Vote: VoteNay},
+		}},
+	})
+	require.Len(t, out, 1)
+	assert.Equal(t, "A", out[0].Version)
+	assert.True(t, out[0].Active)
+	assert.Equal(t, SourceAssembly, out[0].Source)
+	assert.Len(t, out[0].Votes, 2)
+	assert.Empty(t, conflicts)
+
+	// votes on different versions aren't merged, and a vote without a version is ambiguous
+	// when there are votes on two versions the same day
+	out, _ = ReconcileVotes([]Vote{
+		{VoteType: FloorVote, Date: d, Version: "A", Chamber: "ASSEMBLY", Source: SourceOpenLegislation},
+		{VoteType: FloorVote, Date: d, Version: "B", Chamber: "ASSEMBLY", Source: SourceOpenLegislation},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly},
+	})
+	assert.Len(t, out, 3)
+}
+
+func TestReconcileVotesMissingID(t *testing.T) {
+	d := date(2023, 6, 7)
+	out, conflicts := ReconcileVotes([]Vote{
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceAssembly, Votes: []VoteEntry{{Short: "SMITH", Vote: VoteAye}}},
+		{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceOpenLegislation, Votes: []VoteEntry{{ID: 1, Short: "Smith", Vote: VoteAye}}},
+	})
+	require.Len(t, out, 1)
+	assert.Equal(t, []VoteEntry{{ID: 1, Short: "SMITH", Vote: VoteAye}}, out[0].Votes)
+	assert.Empty(t, conflicts)
+}
+
+func TestReconcileVotesWarnings(t *testing.T) {
+	d := date(2023, 6, 7)
+	votes := newVotes([]verboseapi.BillVote{{
+		VoteType: "FLOOR",
+		VoteDate: "2023-06-07",
+		Warnings: []verboseapi.MemberWarning{{
+			Name:   "Rosenthal",
+			Vote:   "Y",
+			Reason: verboseapi.MemberAmbiguous,
+			Candidates: []verboseapi.MemberEntry{
+				{MemberID: 1, ShortName: "ROSENTHAL L"},
+				{MemberID: 2, ShortName: "ROSENTHAL D"},
+			},
+		}},
+	}}, SourceAssembly, "")
+	votes[0].Chamber = "ASSEMBLY"
+	votes = append(votes, Vote{VoteType: FloorVote, Date: d, Chamber: "ASSEMBLY", Source: SourceOpenLegislation})
+
+	out, _ := ReconcileVotes(votes)
+	require.Len(t, out, 1)
+	require.Len(t, out[0].Warnings, 1)
+	w := out[0].Warnings[0]
+	assert.Equal(t, VoteAye, w.Vote)
+	assert.Equal(t, verboseapi.MemberAmbiguous, w.Reason)
+	assert.Equal(t, []Sponsor{{ID: 1, Short: "ROSENTHAL L"}, {ID: 2, Short: "ROSENTHAL D"}}, w.Candidates)
+}
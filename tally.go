@@ -0,0 +1,204 @@
+package nysenateapi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Number of elected members in each chamber
+const (
+	SenateSeats   = 63
+	AssemblySeats = 150
+)
+
+type VoteTally struct {
+	Ayes      int `json:"Ayes"`
+	Nays      int `json:"Nays"`
+	Excused   int `json:"Excused,omitempty"`
+	Absent    int `json:"Absent,omitempty"`
+	Abstained int `json:"Abstained,omitempty"`
+	Other     int `json:"Other,omitempty"`
+}
+
+// Tally counts the vote entries. Votes "with reservations" are counted as Ayes.
+func (v Vote) Tally() VoteTally {
+	var t VoteTally
+	for _, e := range v.Votes {
+		switch e.Vote {
+		case VoteAye:
+			t.Ayes++
+		case VoteNay:
+			t.Nays++
+		case VoteExcused:
+			t.Excused++
+		case VoteAbsent:
+			t.Absent++
+		case VoteAbstained:
+			t.Abstained++
+		default:
+			t.Other++
+		}
+	}
+	return t
+}
+
+// Threshold is the number of votes needed to pass
+type Threshold string
+
+const (
+	// MajorityElected is a majority of the members elected to the chamber (most bills)
+	MajorityElected Threshold = "majority"
+	// TwoThirdsElected is two-thirds of the members elected to the chamber
+	// (veto overrides; special laws without a home rule request)
+	TwoThirdsElected Threshold = "two-thirds"
+)
+
+// RequiredVotes returns the number of ayes needed in a chamber
+func RequiredVotes(chamber string, t Threshold) int {
+	var seats int
+	switch strings.ToUpper(chamber) {
+	case "SENATE":
+		seats = SenateSeats
+	case "ASSEMBLY":
+		seats = AssemblySeats
+	default:
+		return 0
+	}
+	if t == TwoThirdsElected {
+		return (seats*2 + 2) / 3
+	}
+	return seats/2 + 1
+}
+
+type VoteResult struct {
+	VoteTally
+	Threshold Threshold `json:"Threshold"`
+	Required  int       `json:"Required"`
+	Passed    bool      `json:"Passed"`
+}
+
+// chamber returns the chamber a vote was taken in. OpenLegislation only records Senate votes;
+// nyassembly.gov only Assembly votes. It returns "" when the chamber is unknown.
+func (v Vote) chamber() string {
+	switch {
+	case v.Chamber != "":
+		return strings.ToUpper(v.Chamber)
+	case v.Source == SourceOpenLegislation:
+		return "SENATE"
+	case v.Source == SourceAssembly:
+		return "ASSEMBLY"
+	}
+	return ""
+}
+
+// Result determines if a floor vote passed at threshold t. It returns false for committee votes
+// or votes where the chamber is unknown.
+func (v Vote) Result(t Threshold) (VoteResult, bool) {
+	if v.VoteType != FloorVote {
+		return VoteResult{}, false
+	}
+	required := RequiredVotes(v.chamber(), t)
+	if required == 0 {
+		return VoteResult{}, false
+	}
+	tally := v.Tally()
+	return VoteResult{
+		VoteTally: tally,
+		Threshold: t,
+		Required:  required,
+		Passed:    tally.Ayes >= required,
+	}, true
+}
+
+// Threshold returns the votes needed to pass a floor vote on the bill. Two-thirds is required to
+// override a veto (a vote after the bill was vetoed) and for a special law passed on a home rule
+// message of necessity from the governor instead of a home rule request (Article IX §2).
+func (b Bill) Threshold(v Vote) Threshold {
+	var homeRuleRequest, homeRuleNecessity bool
+	for _, e := range b.ActionEvents() {
+		switch e.Type {
+		case ActionVeto:
+			if !v.Date.Before(e.Date) {
+				return TwoThirdsElected
+			}
+		case ActionHomeRule:
+			homeRuleRequest = true
+		case ActionMessageOfNecessity:
+			if strings.Contains(strings.ToUpper(e.Text), "HOME RULE") {
+				homeRuleNecessity = true
+			}
+		}
+	}
+	for _, veto := range b.Vetoes {
+		if veto.SignedDate.IsValid() && !v.Date.Before(veto.SignedDate) {
+			return TwoThirdsElected
+		}
+	}
+	if homeRuleNecessity && !homeRuleRequest {
+		return TwoThirdsElected
+	}
+	return MajorityElected
+}
+
+// VoteResult determines if a floor vote on the bill passed using the Threshold for the vote
+func (b Bill) VoteResult(v Vote) (VoteResult, bool) {
+	return v.Result(b.Threshold(v))
+}
+
+// StatedCounts are vote counts included in action text i.e. "PASSED SENATE (AYES 62 NAYS 1)"
+type StatedCounts struct {
+	Ayes int `json:"Ayes"`
+	Nays int `json:"Nays"`
+}
+
+var (
+	statedCountsPattern = regexp.MustCompile(`(?i)\b(?:AYES|AYE|YEAS|YEA|YES)\s*[:=]?\s*(\d+)\W+(?:NAYS|NAY|NOES|NO)\s*[:=]?\s*(\d+)`)
+	statedScorePattern  = regexp.MustCompile(`\((\d+)\s*-\s*(\d+)\)`)
+)
+
+// ParseStatedCounts finds vote counts in action text
+func ParseStatedCounts(text string) (StatedCounts, bool) {
+	m := statedCountsPattern.FindStringSubmatch(text)
+	if m == nil {
+		m = statedScorePattern.FindStringSubmatch(text)
+	}
+	if m == nil {
+		return StatedCounts{}, false
+	}
+	ayes, _ := strconv.Atoi(m[1])
+	nays, _ := strconv.Atoi(m[2])
+	return StatedCounts{Ayes: ayes, Nays: nays}, true
+}
+
+type VoteMismatch struct {
+	Vote   Vote         `json:"Vote"`
+	Action Action       `json:"Action"`
+	Stated StatedCounts `json:"Stated"`
+	Tally  VoteTally    `json:"Tally"`
+}
+
+// VoteMismatches compares floor vote tallies with counts stated in actions on the same date
+// in the same chamber and returns any that disagree.
+func (b Bill) VoteMismatches() []VoteMismatch {
+	var o []VoteMismatch
+	for _, a := range b.Actions {
+		stated, ok := ParseStatedCounts(a.Text)
+		if !ok {
+			continue
+		}
+		for _, v := range b.Votes {
+			if v.VoteType != FloorVote || v.Date != a.Date {
+				continue
+			}
+			if v.Chamber != "" && a.Chamber != "" && !strings.EqualFold(v.Chamber, a.Chamber) {
+				continue
+			}
+			tally := v.Tally()
+			if tally.Ayes != stated.Ayes || tally.Nays != stated.Nays {
+				o = append(o, VoteMismatch{Vote: v, Action: a, Stated: stated, Tally: tally})
+			}
+		}
+	}
+	return o
+}
@@ -0,0 +1,114 @@
+package nysenateapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func votes(ayes, nays, excused int) []VoteEntry {
+	var o []VoteEntry
+	for i := 0; i < ayes; i++ {
+		o = append(o, VoteEntry{ID: len(o) + 1, Vote: VoteAye})
+	}
+	for i := 0; i < nays; i++ {
+		o = append(o, VoteEntry{ID: len(o) + 1, Vote: VoteNay})
+	}
+	for i := 0; i < excused; i++ {
+		o = append(o, VoteEntry{ID: len(o) + 1, Vote: VoteExcused})
+	}
+	return o
+}
+
+func TestRequiredVotes(t *testing.T) {
+	assert.Equal(t, 32, RequiredVotes("SENATE", MajorityElected))
+	assert.Equal(t, 42, RequiredVotes("SENATE", TwoThirdsElected))
+	assert.Equal(t, 76, RequiredVotes("assembly", MajorityElected))
+	assert.Equal(t, 100, RequiredVotes("ASSEMBLY", TwoThirdsElected))
+	assert.Equal(t, 0, RequiredVotes("", MajorityElected))
+}
+
+func TestVoteResult(t *testing.T) {
+	v := Vote{VoteType: FloorVote, Source: SourceOpenLegislation, Votes: votes(40, 20, 3)}
+	v.Votes = append(v.Votes, VoteEntry{Vote: VoteAbstained}, VoteEntry{Vote: VoteValue("??")})
+	assert.Equal(t, VoteTally{Ayes: 40, Nays: 20, Excused: 3, Abstained: 1, Other: 1}, v.Tally())
+
+	r, ok := v.Result(MajorityElected)
+	assert.True(t, ok)
+	assert.True(t, r.Passed)
+	assert.Equal(t, 32, r.Required)
+
+	r, ok = v.Result(TwoThirdsElected)
+	assert.True(t, ok)
+	assert.False(t, r.Passed)
+
+	// a majority of those voting is not a majority of elected members
+	v = Vote{VoteType: FloorVote, Chamber: "ASSEMBLY", Votes: votes(70, 10, 70)}
+	r, _ = v.Result(MajorityElected)
+	assert.False(t, r.Passed)
+
+	_, ok = Vote{VoteType: CommitteeVote}.Result(MajorityElected)
+	assert.False(t, ok)
+	// unknown chamber
+	_, ok = Vote{VoteType: FloorVote, Votes: votes(40, 20, 3)}.Result(MajorityElected)
+	assert.False(t, ok)
+}
+
+func TestBillThreshold(t *testing.T) {
+	passed := Vote{VoteType: FloorVote, Source: SourceOpenLegislation, Date: date(2023, 6, 7), Votes: votes(40, 20, 3)}
+	override := Vote{VoteType: FloorVote, Source: SourceOpenLegislation, Date: date(2024, 1, 10), Votes: votes(40, 20, 3)}
+	b := Bill{Actions: []Action{
+		{Text: "PASSED SENATE", Date: date(2023, 6, 7)},
+		{Text: "VETOED MEMO.120", Date: date(2023, 12, 22)},
+	}}
+	assert.Equal(t, MajorityElected, b.Threshold(passed))
+	assert.Equal(t, TwoThirdsElected, b.Threshold(override))
+	r, ok := b.VoteResult(override)
+	assert.True(t, ok)
+	assert.Equal(t, 42, r.Required)
+	assert.False(t, r.Passed)
+
+	b = Bill{Vetoes: []Veto{{SignedDate: date(2023, 12, 22)}}}
+	assert.Equal(t, TwoThirdsElected, b.Threshold(override))
+
+	b = Bill{Actions: []Action{{Text: "MESSAGE OF NECESSITY - HOME RULE", Date: date(2023, 6, 6)}}}
+	assert.Equal(t, TwoThirdsElected, b.Threshold(passed))
+	b.Actions = append(b.Actions, Action{Text: "HOME RULE REQUEST", Date: date(2023, 6, 6)})
+	assert.Equal(t, MajorityElected, b.Threshold(passed))
+	b = Bill{Actions: []Action{{Text: "MESSAGE OF NECESSITY - 3 DAY MESSAGE", Date: date(2023, 6, 6)}}}
+	assert.Equal(t, MajorityElected, b.Threshold(passed))
+}
+
+func TestParseStatedCounts(t *testing.T) {
+	tests := map[string]StatedCounts{
+		"PASSED SENATE (AYES 62 NAYS 1)": {62, 1},
+		"passed assembly Yes: 140 No: 8": {140, 8},
+		"Yeas 55, Nays 7":                {55, 7},
+		"PASSED SENATE (61-2)":           {61, 2},
+	}
+	for text, want := range tests {
+		got, ok := ParseStatedCounts(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, want, got, text)
+	}
+	_, ok := ParseStatedCounts("PASSED SENATE")
+	assert.False(t, ok)
+}
+
+func TestVoteMismatches(t *testing.T) {
+	b := Bill{
+		Actions: []Action{
+			{Text: "PASSED SENATE (AYES 62 NAYS 1)", Date: date(2023, 6, 7), Chamber: "SENATE"},
+			{Text: "PASSED SENATE (AYES 60 NAYS 3)", Date: date(2023, 6, 8), Chamber: "SENATE"},
+		},
+		Votes: []Vote{
+			{VoteType: FloorVote, Date: date(2023, 6, 7), Votes: votes(62, 1, 0)},
+			{VoteType: FloorVote, Date: date(2023, 6, 8), Votes: votes(61, 2, 0)},
+		},
+	}
+	m := b.VoteMismatches()
+	if assert.Len(t, m, 1) {
+		assert.Equal(t, StatedCounts{60, 3}, m[0].Stated)
+		assert.Equal(t, 61, m[0].Tally.Ayes)
+	}
+}
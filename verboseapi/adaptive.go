@@ -0,0 +1,122 @@
+package verboseapi
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// AdaptiveLimiter adjusts the rate of a rate.Limiter based on server responses. The rate is reduced when the
+// server returns 429 Too Many Requests or a 5xx error, or responds slower than SlowLatency, and gradually
+// recovers to Max after RecoveryInterval without throttling.
+type AdaptiveLimiter struct {
+	Limiter *rate.Limiter
+	// Max is the rate recovered to; Min is the lowest rate after repeated decreases
+	Max rate.Limit
+	Min rate.Limit
+	// DecreaseFactor multiplies the rate when the server is throttling (default 0.5)
+	DecreaseFactor float64
+	// IncreaseFactor multiplies the rate after each RecoveryInterval without throttling (default 1.5)
+	IncreaseFactor   float64
+	RecoveryInterval time.Duration
+	// SlowLatency is the response time that is treated as the server slowing down; 0 disables
+	SlowLatency time.Duration
+	// Cooldown is the minimum time between decreases so concurrent failed requests only count once
+	Cooldown time.Duration
+
+	now          func() time.Time
+	mutex        sync.Mutex
+	lastDecrease time.Time
+	lastChange   time.Time
+	// holdUntil delays recovery until a Retry-After time
+	holdUntil time.Time
+}
+
+// NewAdaptiveLimiter returns an AdaptiveLimiter that recovers to the current rate of l
+func NewAdaptiveLimiter(l *rate.Limiter) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		Limiter:          l,
+		Max:              l.Limit(),
+		Min:              rate.Every(10 * time.Second),
+		DecreaseFactor:   0.5,
+		IncreaseFactor:   1.5,
+		RecoveryInterval: 10 * time.Second,
+		SlowLatency:      5 * time.Second,
+		Cooldown:         time.Second,
+		now:              time.Now,
+	}
+}
+
+// Throttled reports if a response indicates the server is overloaded
+func Throttled(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// retryAfter parses a Retry-After header in seconds or as an HTTP date
+func retryAfter(h string, now time.Time) time.Duration {
+	if h == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(h); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// Observe adjusts the rate for a response that took latency. A nil resp (a request that failed
+// without a response) backs off the same as a throttled response.
+func (a *AdaptiveLimiter) Observe(resp *http.Response, latency time.Duration) {
+	status := 0
+	var header string
+	if resp != nil {
+		status = resp.StatusCode
+		header = resp.Header.Get("Retry-After")
+	}
+	a.observe(status, header, latency)
+}
+
+func (a *AdaptiveLimiter) observe(status int, retryAfterHeader string, latency time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	now := a.now()
+	if a.lastChange.IsZero() {
+		a.lastChange = now
+	}
+	current := a.Limiter.Limit()
+
+	slow := a.SlowLatency > 0 && latency >= a.SlowLatency
+	// status is 0 when there was no response
+	if status == 0 || Throttled(status) || slow {
+		if wait := retryAfter(retryAfterHeader, now); wait > 0 {
+			if until := now.Add(wait); until.After(a.holdUntil) {
+				a.holdUntil = until
+			}
+		}
+		if !a.lastDecrease.IsZero() && now.Sub(a.lastDecrease) < a.Cooldown {
+			return
+		}
+		next := current * rate.Limit(a.DecreaseFactor)
+		if next < a.Min {
+			next = a.Min
+		}
+		a.Limiter.SetLimit(next)
+		a.lastDecrease, a.lastChange = now, now
+		return
+	}
+
+	if current >= a.Max || now.Before(a.holdUntil) || now.Sub(a.lastChange) < a.RecoveryInterval {
+		return
+	}
+	next := current * rate.Limit(a.IncreaseFactor)
+	if next > a.Max {
+		next = a.Max
+	}
+	a.Limiter.SetLimit(next)
+	a.lastChange = now
+}
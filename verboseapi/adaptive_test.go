@@ -0,0 +1,153 @@
+package verboseapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func testAdaptive(clock *fakeClock) *AdaptiveLimiter {
+	a := NewAdaptiveLimiter(rate.NewLimiter(100, 1))
+	a.Min = 1
+	a.now = clock.Now
+	return a
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	a := testAdaptive(clock)
+
+	type step struct {
+		advance    time.Duration
+		status     int
+		retryAfter string
+		latency    time.Duration
+		expected   rate.Limit
+	}
+	for i, s := range []step{
+		{status: 200, latency: time.Millisecond, expected: 100},
+		{status: 429, expected: 50},
+		// concurrent failures within the cooldown count once
+		{status: 503, expected: 50},
+		{advance: 2 * time.Second, status: 500, expected: 25},
+		{advance: 2 * time.Second, status: 200, latency: 6 * time.Second, expected: 12.5},
+		{advance: 2 * time.Second, status: 429, expected: 6.25},
+		{advance: 2 * time.Second, status: 429, expected: 3.125},
+		{advance: 2 * time.Second, status: 429, expected: 1.5625},
+		// limited to Min
+		{advance: 2 * time.Second, status: 429, expected: 1},
+		// no recovery until RecoveryInterval after the last change
+		{advance: 5 * time.Second, status: 200, expected: 1},
+		{advance: 5 * time.Second, status: 200, expected: 1.5},
+		{advance: time.Second, status: 200, expected: 1.5},
+		{advance: 10 * time.Second, status: 200, expected: 2.25},
+		{advance: 10 * time.Second, status: 404, expected: 3.375},
+		// a request without a response backs off
+		{advance: 10 * time.Second, status: 0, expected: 1.6875},
+	} {
+		clock.Advance(s.advance)
+		a.observe(s.status, s.retryAfter, s.latency)
+		if got := a.Limiter.Limit(); got != s.expected {
+			t.Fatalf("step %d got %v expected %v", i, got, s.expected)
+		}
+	}
+
+	// recovers to Max
+	for i := 0; i < 20; i++ {
+		clock.Advance(10 * time.Second)
+		a.observe(200, "", time.Millisecond)
+	}
+	if got := a.Limiter.Limit(); got != 100 {
+		t.Errorf("got %v expected recovery to 100", got)
+	}
+}
+
+func TestAdaptiveLimiterRetryAfter(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	a := testAdaptive(clock)
+	a.observe(429, "60", 0)
+	if got := a.Limiter.Limit(); got != 50 {
+		t.Fatalf("got %v", got)
+	}
+	// held until Retry-After passes
+	clock.Advance(30 * time.Second)
+	a.observe(200, "", 0)
+	if got := a.Limiter.Limit(); got != 50 {
+		t.Fatalf("got %v expected no recovery before Retry-After", got)
+	}
+	clock.Advance(31 * time.Second)
+	a.observe(200, "", 0)
+	if got := a.Limiter.Limit(); got != 75 {
+		t.Fatalf("got %v expected recovery after Retry-After", got)
+	}
+
+	if d := retryAfter(clock.Now().Add(time.Minute).Format(http.TimeFormat), clock.Now()); d != time.Minute {
+		t.Errorf("got Retry-After %s", d)
+	}
+}
+
+func TestAdaptiveLimiterServer(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success":false,"message":"Too many requests"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer ts.Close()
+
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	a := NewAPI("token")
+	a.BaseURL = ts.URL
+	a.Limiter = rate.NewLimiter(1000, 1)
+	a.Adaptive = NewAdaptiveLimiter(a.Limiter)
+	a.Adaptive.now = clock.Now
+
+	ctx := context.Background()
+	var v struct {
+		Success bool `json:"success"`
+	}
+	// throttled responses are errors
+	if err := a.get(ctx, "Bills", "/api/3/bills", nil, &v); err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("got %v expected a 429 error", err)
+	}
+	if got := a.Limiter.Limit(); got != 500 {
+		t.Fatalf("got %v expected rate to be reduced", got)
+	}
+	clock.Advance(2 * time.Second)
+	if err := a.get(ctx, "Bills", "/api/3/bills", nil, &v); err == nil {
+		t.Fatal("expected an error")
+	}
+	if v.Success {
+		t.Errorf("expected throttled responses not to be decoded")
+	}
+	if got := a.Limiter.Limit(); got != 250 {
+		t.Fatalf("got %v expected rate to be reduced", got)
+	}
+	clock.Advance(15 * time.Second)
+	if err := a.get(ctx, "Bills", "/api/3/bills", nil, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Success {
+		t.Errorf("expected success")
+	}
+	if got := a.Limiter.Limit(); got != 375 {
+		t.Fatalf("got %v expected rate to recover", got)
+	}
+}
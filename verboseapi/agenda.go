@@ -0,0 +1,109 @@
+package verboseapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// GetCommitteeMeetings returns the committee agendas for meetings in the given time range.
+// https://legislation.nysenate.gov/static/docs/html/agendas.html#committee-meetings-in-a-date-range
+func (a NYSenateAPI) GetCommitteeMeetings(ctx context.Context, from, to time.Time) (*CommitteeAgendasResponse, error) {
+	// /api/3/agendas/meetings/{from}/{to}
+	a.logger().DebugContext(ctx, "committee meetings", "from", from, "to", to)
+	path := fmt.Sprintf("/api/3/agendas/meetings/%s/%s", from.Format(timeFormat), to.Format(timeFormat))
+	var data CommitteeAgendasResponse
+	err := a.get(ctx, "GetCommitteeMeetings", path, nil, &data)
+	return &data, err
+}
+
+// GetAgenda returns a weekly agenda with all of its committee meetings
+// https://legislation.nysenate.gov/static/docs/html/agendas.html#get-a-single-agenda
+func (a NYSenateAPI) GetAgenda(ctx context.Context, year, agendaNo int) (*AgendaResponse, error) {
+	if year == 0 || agendaNo == 0 {
+		return nil, nil
+	}
+	a.logger().DebugContext(ctx, "looking up agenda", "year", year, "agendaNo", agendaNo)
+	path := fmt.Sprintf("/api/3/agendas/%d/%d", year, agendaNo)
+	var data AgendaResponse
+	err := a.get(ctx, "GetAgenda", path, &url.Values{}, &data)
+	return &data, err
+}
+
+type AgendaID struct {
+	Number int `json:"number"`
+	Year   int `json:"year"`
+}
+
+type CommitteeID struct {
+	Chamber string `json:"chamber"` // SENATE
+	Name    string `json:"name"`
+}
+
+type AgendaResponse struct {
+	Envelope
+	Result Agenda `json:"result"`
+}
+
+type Agenda struct {
+	ID                   AgendaID `json:"id"`
+	WeekOf               string   `json:"weekOf"` // i.e. "2017-01-09"
+	PublishedDateTime    string   `json:"publishedDateTime"`
+	ModifiedDateTime     string   `json:"modifiedDateTime"`
+	TotalAddendum        int      `json:"totalAddendum"`
+	TotalBillsConsidered int      `json:"totalBillsConsidered"`
+	TotalBillsVotedOn    int      `json:"totalBillsVotedOn"`
+	TotalCommittees      int      `json:"totalCommittees"`
+	CommitteeAgendas     struct {
+		Items []CommitteeAgenda `json:"items,omitempty"`
+		Size  int               `json:"size,omitempty"`
+	} `json:"committeeAgendas"`
+}
+
+type CommitteeAgendasResponse struct {
+	Envelope
+	Result struct {
+		Items []CommitteeAgenda `json:"items"`
+		Size  int               `json:"size"`
+	} `json:"result"`
+}
+
+type CommitteeAgenda struct {
+	AgendaID    AgendaID    `json:"agendaId"`
+	CommitteeID CommitteeID `json:"committeeId"`
+	Addenda     struct {
+		Items []CommitteeAgendaAddendum `json:"items,omitempty"`
+		Size  int                       `json:"size,omitempty"`
+	} `json:"addenda"`
+}
+
+type CommitteeAgendaAddendum struct {
+	AddendumID       string  `json:"addendumId"` // "" for the original, then "A", "B", ...
+	ModifiedDateTime string  `json:"modifiedDateTime"`
+	HasVotes         bool    `json:"hasVotes"`
+	Meeting          Meeting `json:"meeting"`
+	Bills            struct {
+		Items []AgendaBill `json:"items,omitempty"`
+		Size  int          `json:"size,omitempty"`
+	} `json:"bills"`
+}
+
+type Meeting struct {
+	Chair           string `json:"chair"`
+	Location        string `json:"location"`
+	MeetingDateTime string `json:"meetingDateTime"` // i.e. "2017-01-10T09:30"
+	Notes           string `json:"notes"`
+}
+
+type AgendaBill struct {
+	BillID   BillID `json:"billId"`
+	BillInfo struct {
+		BillID
+		Title   string `json:"title"`
+		Sponsor struct {
+			Member MemberEntry `json:"member"`
+		} `json:"sponsor"`
+	} `json:"billInfo"`
+	Message string `json:"message"`
+}
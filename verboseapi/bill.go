@@ -2,11 +2,13 @@ package verboseapi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
-
-	log "github.com/sirupsen/logrus"
 )
 
 func (a NYSenateAPI) Bills(ctx context.Context, session string, offset int) (*BillsResponse, error) {
@@ -16,8 +18,28 @@ func (a NYSenateAPI) Bills(ctx context.Context, session string, offset int) (*Bi
 	params := &url.Values{"offset": []string{fmt.Sprintf("%d", offset)}, "limit": []string{"1000"}}
 	path := fmt.Sprintf("/api/3/bills/%s", url.PathEscape(session))
 	var data BillsResponse
-	log.WithContext(ctx).WithField("offset", offset).WithField("session", session).Debugf("bills session:%s", session)
-	err := a.get(ctx, path, params, &data)
+	a.logger().DebugContext(ctx, "bills", "session", session, "offset", offset)
+	err := a.get(ctx, "Bills", path, params, &data)
+	return &data, err
+}
+
+// SearchBills searches bills in a session ("" for all sessions) using the ElasticSearch query syntax.
+// https://legislation.nysenate.gov/static/docs/html/bills.html#search-for-bills
+func (a NYSenateAPI) SearchBills(ctx context.Context, session, term string, offset int) (*BillSearchResponse, error) {
+	if term == "" {
+		return nil, nil
+	}
+	params := &url.Values{"term": []string{term}, "limit": []string{"100"}}
+	if offset > 1 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	path := "/api/3/bills/search"
+	if session != "" {
+		path = fmt.Sprintf("/api/3/bills/%s/search", url.PathEscape(session))
+	}
+	var data BillSearchResponse
+	a.logger().DebugContext(ctx, "bill search", "session", session, "term", term)
+	err := a.get(ctx, "SearchBills", path, params, &data)
 	return &data, err
 }
 
@@ -29,7 +51,7 @@ func (a NYSenateAPI) GetBillUpdates(ctx context.Context, from, to time.Time, off
 	// /api/3/bills/updates/{fromDateTime}
 	// should be inputted as 2014-12-10T13:30:02.
 	// The fromDateTime and toDateTime range is exclusive/inclusive respectively.
-	log.WithContext(ctx).WithField("from", from).WithField("to", to).Debugf("bill updates")
+	a.logger().DebugContext(ctx, "bill updates", "from", from, "to", to)
 	path := fmt.Sprintf("/api/3/bills/updates/%s/%s", from.Format(timeFormat), to.Format(timeFormat))
 	params := &url.Values{}
 	params.Set("type", "processed")
@@ -38,7 +60,7 @@ func (a NYSenateAPI) GetBillUpdates(ctx context.Context, from, to time.Time, off
 		params.Set("offset", fmt.Sprintf("%d", offset))
 	}
 	var data BillUpdateResponse
-	err := a.get(ctx, path, params, &data)
+	err := a.get(ctx, "GetBillUpdates", path, params, &data)
 	return &data, err
 }
 
@@ -88,6 +110,19 @@ type Envelope struct {
 	Limit        int    `json:"limit"`
 }
 
+type BillSearchResponse struct {
+	Envelope
+	Result struct {
+		Items []BillSearchResult `json:"items"`
+		Size  int                `json:"size"`
+	} `json:"result"`
+}
+
+type BillSearchResult struct {
+	Result BillReference `json:"result"`
+	Rank   float64       `json:"rank"`
+}
+
 type BillsResponse struct {
 	Envelope
 	Result struct {
@@ -96,6 +131,7 @@ type BillsResponse struct {
 	} `json:"result"`
 }
 
+// GetBill returns nil when the bill is not found
 func (a NYSenateAPI) GetBill(ctx context.Context, session, printNo string) (*Bill, error) {
 	if session == "" || printNo == "" {
 		return nil, nil
@@ -104,8 +140,45 @@ func (a NYSenateAPI) GetBill(ctx context.Context, session, printNo string) (*Bil
 	// params.Set("view", "with_refs")
 	path := fmt.Sprintf("/api/3/bills/%s/%s", url.PathEscape(session), url.PathEscape(printNo))
 	var data BillResponse
-	log.WithContext(ctx).WithField("session", session).WithField("printNo", printNo).Debugf("looking up bill %s-%s", session, printNo)
-	err := a.get(ctx, path, params, &data)
+	a.logger().DebugContext(ctx, "looking up bill", "session", session, "printNo", printNo)
+	err := a.get(ctx, "GetBill", path, params, &data)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return &(data.Bill), err
+}
+
+type FullTextFormat string
+
+const (
+	PlainText    FullTextFormat = "PLAIN"
+	HTMLText     FullTextFormat = "HTML"
+	TemplateText FullTextFormat = "TEMPLATE"
+)
+
+// GetBillText returns a bill with the full text of each amendment populated in the requested formats
+// (FullText, FullTextHTML, FullTextTemplate). PLAIN is used when no format is specified.
+// It returns nil when the bill is not found.
+//
+// https://legislation.nysenate.gov/static/docs/html/bills.html#bill-text-formats
+func (a NYSenateAPI) GetBillText(ctx context.Context, session, printNo string, formats ...FullTextFormat) (*Bill, error) {
+	if session == "" || printNo == "" {
+		return nil, nil
+	}
+	if len(formats) == 0 {
+		formats = []FullTextFormat{PlainText}
+	}
+	params := &url.Values{}
+	for _, f := range formats {
+		params.Add("fullTextFormat", string(f))
+	}
+	path := fmt.Sprintf("/api/3/bills/%s/%s", url.PathEscape(session), url.PathEscape(printNo))
+	var data BillResponse
+	a.logger().DebugContext(ctx, "looking up bill text", "session", session, "printNo", printNo)
+	err := a.get(ctx, "GetBillText", path, params, &data)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
 	return &(data.Bill), err
 }
 
@@ -124,109 +197,38 @@ type Bill struct {
 		Desc       string `json:"desc"`
 		Resolution bool   `json:"resolution"`
 	} `json:"billType"`
-	Title             string `json:"title"`
-	ActiveVersion     string `json:"activeVersion,omitempty"`
-	Year              int    `json:"year"`
-	PublishedDateTime string `json:"publishedDateTime"`
-	SubstitutedBy     BillID `json:"substitutedBy,omitempty"`
-	Sponsor           struct {
-		Member MemberEntry `json:"member"`
-		Budget bool        `json:"budget"`
-		Rules  bool        `json:"rules"`
-	} `json:"sponsor"`
-	Summary string `json:"summary"`
-	Signed  bool   `json:"signed"`
-	Status  struct {
-		StatusType    string      `json:"statusType"`
-		StatusDesc    string      `json:"statusDesc"`
-		ActionDate    string      `json:"actionDate"`
-		CommitteeName string      `json:"committeeName"`
-		BillCalNo     interface{} `json:"billCalNo,omitempty"`
-	} `json:"status"`
-	Milestones struct {
-		Items []struct {
-			StatusType    string      `json:"statusType"`
-			StatusDesc    string      `json:"statusDesc"`
-			ActionDate    string      `json:"actionDate"`
-			CommitteeName string      `json:"committeeName"`
-			BillCalNo     interface{} `json:"billCalNo,omitempty"`
-		} `json:"items,omitempty"`
-		Size int `json:"size"`
-	} `json:"milestones"`
-	ProgramInfo struct {
-		Name       string `json:"name,omitempty"`
-		SequenceNo int    `json:"sequenceNo,omitempty"`
-	} `json:"programInfo,omitempty"`
-	Amendments struct {
-		Items map[string]struct {
-			BillID
-			PublishDate string `json:"publishDate"`
-			SameAs      struct {
-				Items []BillID `json:"items,omitempty"`
-				Size  int      `json:"size,omitempty"`
-			} `json:"sameAs,omitempty"`
-			Memo             string          `json:"memo"`
-			LawSection       string          `json:"lawSection"`
-			LawCode          string          `json:"lawCode"`
-			ActClause        string          `json:"actClause"`
-			FullTextFormats  []string        `json:"fullTextFormats"`
-			FullText         string          `json:"fullText"`
-			FullTextHTML     string          `json:"fullTextHtml,omitempty"`
-			FullTextTemplate string          `json:"fullTextTemplate,omitempty"`
-			CoSponsors       MemberEntryList `json:"coSponsors"`
-			MultiSponsors    MemberEntryList `json:"multiSponsors"`
-			UniBill          bool            `json:"uniBill"`
-			Stricken         bool            `json:"stricken"`
-		} `json:"items,omitempty"`
-		Size int `json:"size"`
+	Title             string          `json:"title"`
+	ActiveVersion     string          `json:"activeVersion,omitempty"`
+	Year              int             `json:"year"`
+	PublishedDateTime string          `json:"publishedDateTime"`
+	SubstitutedBy     BillID          `json:"substitutedBy,omitempty"`
+	Sponsor           BillSponsor     `json:"sponsor"`
+	Summary           string          `json:"summary"`
+	Signed            bool            `json:"signed"`
+	Status            BillStatus      `json:"status"`
+	Milestones        BillMilestones  `json:"milestones"`
+	ProgramInfo       BillProgramInfo `json:"programInfo,omitempty"`
+	Amendments        struct {
+		Items map[string]Amendment `json:"items,omitempty"`
+		Size  int                  `json:"size"`
 	} `json:"amendments"`
 	Votes struct {
 		Items []BillVote `json:"items,omitempty"`
 		Size  int        `json:"size,omitempty"`
 	} `json:"votes,omitempty"`
 	VetoMessages struct {
-		Items []struct {
-			BillID     BillID      `json:"billId"`
-			Year       int         `json:"year"`
-			VetoNumber int         `json:"vetoNumber"`
-			MemoText   string      `json:"memoText"`
-			VetoType   string      `json:"vetoType"`
-			Chapter    int         `json:"chapter"`
-			BillPage   int         `json:"billPage"`
-			LineStart  int         `json:"lineStart"`
-			LineEnd    int         `json:"lineEnd"`
-			Signer     string      `json:"signer"`
-			SignedDate interface{} `json:"signedDate"`
-		} `json:"items,omitempty"`
-		Size int `json:"size,omitempty"`
+		Items []VetoMessage `json:"items,omitempty"`
+		Size  int           `json:"size,omitempty"`
 	} `json:"vetoMessages,omitempty"`
-	ApprovalMessage struct {
-		BillID         BillID `json:"billId,omitempty"`
-		Year           int    `json:"year,omitempty"`
-		ApprovalNumber int    `json:"approvalNumber,omitempty"`
-		Chapter        int    `json:"chapter,omitempty"`
-		Signer         string `json:"signer,omitempty"`
-		Text           string `json:"text,omitempty"`
-	} `json:"approvalMessage,omitempty"`
+	ApprovalMessage    ApprovalMessage `json:"approvalMessage,omitempty"`
 	AdditionalSponsors MemberEntryList `json:"additionalSponsors,omitempty"`
 	PastCommittees     struct {
-		Items []struct {
-			Chamber       string `json:"chamber"`
-			Name          string `json:"name"`
-			SessionYear   int    `json:"sessionYear"`
-			ReferenceDate string `json:"referenceDate"`
-		} `json:"items,omitempty"`
-		Size int `json:"size,omitempty"`
+		Items []PastCommittee `json:"items,omitempty"`
+		Size  int             `json:"size,omitempty"`
 	} `json:"pastCommittees,omitempty"`
 	Actions struct {
-		Items []struct {
-			BillID     BillID `json:"billId"`
-			Date       string `json:"date"`
-			Chamber    string `json:"chamber"`
-			SequenceNo int    `json:"sequenceNo"`
-			Text       string `json:"text"`
-		} `json:"items,omitempty"`
-		Size int `json:"size,omitempty"`
+		Items []BillAction `json:"items,omitempty"`
+		Size  int          `json:"size,omitempty"`
 	} `json:"actions"`
 	PreviousVersions struct {
 		Items []BillID `json:"items,omitempty"`
@@ -252,30 +254,195 @@ type Bill struct {
 		} `json:"items,omitempty"`
 		Size int `json:"size,omitempty"`
 	} `json:"calendars,omitempty"`
+	// BillInfoRefs are summaries of related bills keyed by print number (i.e. "S1234-2023") when requested with view=with_refs
 	BillInfoRefs struct {
-		Items interface{} `json:"items,omitempty"`
-		Size  int         `json:"size,omitempty"`
+		Items map[string]BillInfo `json:"items,omitempty"`
+		Size  int                 `json:"size,omitempty"`
 	} `json:"billInfoRefs,omitempty"`
 }
 
+type BillSponsor struct {
+	Member MemberEntry `json:"member"`
+	Budget bool        `json:"budget"`
+	Rules  bool        `json:"rules"`
+}
+
+type BillStatus struct {
+	StatusType    string `json:"statusType"`
+	StatusDesc    string `json:"statusDesc"`
+	ActionDate    string `json:"actionDate"`
+	CommitteeName string `json:"committeeName"`
+	// BillCalNo is the floor calendar number; 0 when the bill is not on a calendar
+	BillCalNo int `json:"billCalNo,omitempty"`
+}
+
+type BillMilestones struct {
+	Items []BillStatus `json:"items,omitempty"`
+	Size  int          `json:"size"`
+}
+
+type BillProgramInfo struct {
+	Name       string `json:"name,omitempty"`
+	SequenceNo int    `json:"sequenceNo,omitempty"`
+}
+
+// BillInfo is the summary of a bill returned in BillInfoRefs
+type BillInfo struct {
+	BillReference
+	SubstitutedBy BillID          `json:"substitutedBy,omitempty"`
+	Sponsor       BillSponsor     `json:"sponsor"`
+	Summary       string          `json:"summary"`
+	Signed        bool            `json:"signed"`
+	Adopted       bool            `json:"adopted"`
+	Vetoed        bool            `json:"vetoed"`
+	Status        BillStatus      `json:"status"`
+	Milestones    BillMilestones  `json:"milestones"`
+	ProgramInfo   BillProgramInfo `json:"programInfo,omitempty"`
+}
+
+// Amendment is a single version of a bill. The original print has an empty Version.
+type Amendment struct {
+	BillID
+	PublishDate string `json:"publishDate"`
+	SameAs      struct {
+		Items []BillID `json:"items,omitempty"`
+		Size  int      `json:"size,omitempty"`
+	} `json:"sameAs,omitempty"`
+	Memo             string          `json:"memo"`
+	LawSection       string          `json:"lawSection"`
+	LawCode          string          `json:"lawCode"`
+	ActClause        string          `json:"actClause"`
+	FullTextFormats  []string        `json:"fullTextFormats"`
+	FullText         string          `json:"fullText"`
+	FullTextHTML     string          `json:"fullTextHtml,omitempty"`
+	FullTextTemplate string          `json:"fullTextTemplate,omitempty"`
+	CoSponsors       MemberEntryList `json:"coSponsors"`
+	MultiSponsors    MemberEntryList `json:"multiSponsors"`
+	UniBill          bool            `json:"uniBill"`
+	Stricken         bool            `json:"stricken"`
+}
+
+type PastCommittee struct {
+	Chamber       string `json:"chamber"`
+	Name          string `json:"name"`
+	SessionYear   int    `json:"sessionYear"`
+	ReferenceDate string `json:"referenceDate"`
+}
+
+type BillAction struct {
+	BillID     BillID `json:"billId"`
+	Date       string `json:"date"`
+	Chamber    string `json:"chamber"`
+	SequenceNo int    `json:"sequenceNo"`
+	Text       string `json:"text"`
+}
+
 type BillVote struct {
-	Version   string `json:"version"`
-	VoteType  string `json:"voteType"`
-	VoteDate  string `json:"voteDate"`
+	Version  string `json:"version"`
+	VoteType string `json:"voteType"`
+	VoteDate string `json:"voteDate"`
+	// Outcome is the committee action on nyassembly.gov votes i.e. "Favorable refer to committee Ways and Means"
+	Outcome   string `json:"outcome,omitempty"`
 	Committee struct {
 		Chamber string `json:"chamber,omitempty"`
 		Name    string `json:"name,omitempty"`
+		// Chair is set on nyassembly.gov committee votes
+		Chair string `json:"chair,omitempty"`
 	} `json:"committee,omitempty"`
 	MemberVotes struct {
 		Items MemberVotes `json:"items,omitempty"`
 		Size  int         `json:"size,omitempty"`
 	} `json:"memberVotes"`
+	// Warnings are member names on nyassembly.gov votes that were not matched with full confidence
+	Warnings []MemberWarning `json:"warnings,omitempty"`
 }
 
 type MemberVotes struct {
 	Aye                 MemberEntryList `json:"AYE,omitempty"`
 	AyeWithReservations MemberEntryList `json:"AYEWR,omitempty"`
-	Nay                 MemberEntryList `json:"NAY,omitempty"` // ?
-	Excused             MemberEntryList `json:"EXC,omitempty"` // excused
-	Absent              MemberEntryList `json:"Absent,omitempty"`
+	Nay                 MemberEntryList `json:"NAY,omitempty"`
+	Excused             MemberEntryList `json:"EXC,omitempty"`
+	Absent              MemberEntryList `json:"ABS,omitempty"`
+	Abstained           MemberEntryList `json:"ABD,omitempty"`
+	// Other holds any other vote codes returned by the API
+	Other map[string]MemberEntryList `json:"-"`
+}
+
+// vote codes in the order they are listed by MemberVotes.Codes
+var voteCodes = []string{"AYE", "AYEWR", "NAY", "EXC", "ABS", "ABD"}
+
+func (m *MemberVotes) list(code string) *MemberEntryList {
+	switch strings.ToUpper(code) {
+	case "AYE":
+		return &m.Aye
+	case "AYEWR":
+		return &m.AyeWithReservations
+	case "NAY":
+		return &m.Nay
+	case "EXC":
+		return &m.Excused
+	case "ABS", "ABSENT":
+		return &m.Absent
+	case "ABD":
+		return &m.Abstained
+	}
+	return nil
+}
+
+func (m *MemberVotes) UnmarshalJSON(b []byte) error {
+	var raw map[string]MemberEntryList
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*m = MemberVotes{}
+	for code, l := range raw {
+		if dst := m.list(code); dst != nil {
+			*dst = l
+			continue
+		}
+		if m.Other == nil {
+			m.Other = make(map[string]MemberEntryList)
+		}
+		m.Other[code] = l
+	}
+	return nil
+}
+
+func (m MemberVotes) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]MemberEntryList)
+	for code, l := range m.Other {
+		raw[code] = l
+	}
+	for _, code := range voteCodes {
+		if l := m.list(code); len(l.Items) > 0 {
+			raw[code] = *l
+		}
+	}
+	return json.Marshal(raw)
+}
+
+type MemberVoteCode struct {
+	Code    string
+	Members []MemberEntry
+}
+
+// Codes returns the members for each vote code; known codes first followed by other codes sorted by name
+func (m MemberVotes) Codes() []MemberVoteCode {
+	var o []MemberVoteCode
+	for _, code := range voteCodes {
+		if l := m.list(code); len(l.Items) > 0 {
+			o = append(o, MemberVoteCode{Code: code, Members: l.Items})
+		}
+	}
+	var other []string
+	for code := range m.Other {
+		other = append(other, code)
+	}
+	sort.Strings(other)
+	for _, code := range other {
+		if len(m.Other[code].Items) > 0 {
+			o = append(o, MemberVoteCode{Code: code, Members: m.Other[code].Items})
+		}
+	}
+	return o
 }
@@ -0,0 +1,57 @@
+package verboseapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetCalendars returns the floor calendars for a year. Each calendar corresponds to a session day.
+// https://legislation.nysenate.gov/static/docs/html/calendars.html#get-a-list-of-calendars
+func (a NYSenateAPI) GetCalendars(ctx context.Context, year int, offset int) (*CalendarsResponse, error) {
+	if year == 0 {
+		return nil, nil
+	}
+	params := &url.Values{"full": []string{"true"}, "limit": []string{"100"}}
+	if offset > 1 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	path := fmt.Sprintf("/api/3/calendars/%d", year)
+	a.logger().DebugContext(ctx, "calendars", "year", year, "offset", offset)
+	var data CalendarsResponse
+	err := a.get(ctx, "GetCalendars", path, params, &data)
+	return &data, err
+}
+
+type CalendarsResponse struct {
+	Envelope
+	Result struct {
+		Items []Calendar `json:"items"`
+		Size  int        `json:"size"`
+	} `json:"result"`
+}
+
+type Calendar struct {
+	Year           int    `json:"year"`
+	CalendarNumber int    `json:"calendarNumber"`
+	CalDate        string `json:"calDate"` // i.e. "2014-06-09"
+	FloorCalendar  struct {
+		Year             int                          `json:"year"`
+		CalendarNumber   int                          `json:"calendarNumber"`
+		CalDate          string                       `json:"calDate"`
+		ReleaseDateTime  string                       `json:"releaseDateTime"`
+		EntriesBySection map[string]CalendarEntryList `json:"entriesBySection"`
+	} `json:"floorCalendar"`
+}
+
+type CalendarEntryList struct {
+	Items []CalendarEntry `json:"items,omitempty"`
+	Size  int             `json:"size,omitempty"`
+}
+
+type CalendarEntry struct {
+	BillID
+	BillCalNo   int    `json:"billCalNo"`
+	SectionType string `json:"sectionType"` // i.e. "THIRD_READING"
+	Title       string `json:"title"`
+}
@@ -0,0 +1,254 @@
+package verboseapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaDrift is the difference between a response and the types it was decoded into
+type SchemaDrift struct {
+	Endpoint string
+	// UnknownFields are paths of fields not in the Go type i.e. "result.status.newField"
+	UnknownFields []string
+	// TypeMismatches are paths of values that don't match the Go type i.e. "result.year: expected int got string"
+	TypeMismatches []string
+}
+
+func (d SchemaDrift) Empty() bool {
+	return len(d.UnknownFields) == 0 && len(d.TypeMismatches) == 0
+}
+
+// EndpointDrift is the schema drift observed for an endpoint in strict mode
+type EndpointDrift struct {
+	// Responses is the number of responses checked; Drifted the number with any drift
+	Responses int
+	Drifted   int
+	// UnknownFields and TypeMismatches count responses by path
+	UnknownFields  map[string]int
+	TypeMismatches map[string]int
+}
+
+type driftStats struct {
+	sync.Mutex
+	endpoints map[string]*EndpointDrift
+}
+
+func (s *driftStats) record(d SchemaDrift) {
+	s.Lock()
+	defer s.Unlock()
+	if s.endpoints == nil {
+		s.endpoints = make(map[string]*EndpointDrift)
+	}
+	e, ok := s.endpoints[d.Endpoint]
+	if !ok {
+		e = &EndpointDrift{UnknownFields: make(map[string]int), TypeMismatches: make(map[string]int)}
+		s.endpoints[d.Endpoint] = e
+	}
+	e.Responses++
+	if !d.Empty() {
+		e.Drifted++
+	}
+	for _, p := range d.UnknownFields {
+		e.UnknownFields[p]++
+	}
+	for _, p := range d.TypeMismatches {
+		e.TypeMismatches[p]++
+	}
+}
+
+// DriftReport returns the schema drift by endpoint for responses decoded in Strict mode
+func (a NYSenateAPI) DriftReport() map[string]EndpointDrift {
+	o := make(map[string]EndpointDrift)
+	if a.drift == nil {
+		return o
+	}
+	a.drift.Lock()
+	defer a.drift.Unlock()
+	for endpoint, e := range a.drift.endpoints {
+		c := *e
+		c.UnknownFields = make(map[string]int, len(e.UnknownFields))
+		for k, v := range e.UnknownFields {
+			c.UnknownFields[k] = v
+		}
+		c.TypeMismatches = make(map[string]int, len(e.TypeMismatches))
+		for k, v := range e.TypeMismatches {
+			c.TypeMismatches[k] = v
+		}
+		o[endpoint] = c
+	}
+	return o
+}
+
+// checkDrift records and logs schema drift for a response
+func (a NYSenateAPI) checkDrift(ctx context.Context, endpoint string, body []byte, v interface{}) {
+	d, err := schemaDrift(endpoint, body, v)
+	if err != nil {
+		return
+	}
+	if !d.Empty() {
+		a.logger().WarnContext(ctx, "OpenLegislation schema drift", "endpoint", endpoint, "unknown_fields", d.UnknownFields, "type_mismatches", d.TypeMismatches)
+	}
+	if a.drift != nil {
+		a.drift.record(d)
+	}
+}
+
+// schemaDrift compares the JSON in body with the type of v. Map keys and slice indexes
+// are collapsed in paths (i.e. "result.amendments.items.*.sameAs", "result.actions.items[]")
+func schemaDrift(endpoint string, body []byte, v interface{}) (SchemaDrift, error) {
+	d := SchemaDrift{Endpoint: endpoint}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return d, err
+	}
+	unknown, mismatch := make(map[string]bool), make(map[string]bool)
+	compareType(data, reflect.TypeOf(v), "", unknown, mismatch)
+	for p := range unknown {
+		d.UnknownFields = append(d.UnknownFields, p)
+	}
+	for p := range mismatch {
+		d.TypeMismatches = append(d.TypeMismatches, p)
+	}
+	sort.Strings(d.UnknownFields)
+	sort.Strings(d.TypeMismatches)
+	return d, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
+
+func compareType(data interface{}, t reflect.Type, path string, unknown, mismatch map[string]bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if data == nil || t == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	// types with custom decoding are trusted
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+	mismatched := func() {
+		mismatch[fmt.Sprintf("%s: expected %s got %s", path, t, jsonKind(data))] = true
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			mismatched()
+			return
+		}
+		fields := jsonFields(t)
+		for key, value := range obj {
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown[joinPath(path, key)] = true
+				continue
+			}
+			compareType(value, ft, joinPath(path, key), unknown, mismatch)
+		}
+	case reflect.Map:
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			mismatched()
+			return
+		}
+		for _, value := range obj {
+			compareType(value, t.Elem(), joinPath(path, "*"), unknown, mismatch)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := data.([]interface{})
+		if !ok {
+			mismatched()
+			return
+		}
+		for _, value := range items {
+			compareType(value, t.Elem(), path+"[]", unknown, mismatch)
+		}
+	case reflect.String:
+		if _, ok := data.(string); !ok {
+			mismatched()
+		}
+	case reflect.Bool:
+		if _, ok := data.(bool); !ok {
+			mismatched()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := data.(float64); !ok || n != float64(int64(n)) {
+			mismatched()
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := data.(float64); !ok {
+			mismatched()
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields returns the types of the fields of struct t by lowercase JSON name, including promoted fields
+// of embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			et := ft
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				for k, v := range jsonFields(et) {
+					// fields of the outer struct take precedence
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(opts, "string") {
+			ft = reflect.TypeOf("")
+		}
+		fields[strings.ToLower(name)] = ft
+	}
+	return fields
+}
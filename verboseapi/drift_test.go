@@ -0,0 +1,89 @@
+package verboseapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestSchemaDrift(t *testing.T) {
+	type testCase struct {
+		name     string
+		body     string
+		unknown  []string
+		mismatch []string
+	}
+	tests := []testCase{
+		{"match", `{"success":true,"result":{"basePrintNo":"S1234","session":2023,"status":{"billCalNo":12},"billInfoRefs":{"items":{"S1-2023":{"basePrintNo":"S1","adopted":false}},"size":1}}}`, nil, nil},
+		{"nulls", `{"result":{"title":null,"status":{"billCalNo":null},"vetoMessages":{"items":[{"signedDate":null}]}}}`, nil, nil},
+		{"unknown", `{"result":{"newField":1,"status":{"statusType":"IN_SENATE_COMM","extra":"x"},"actions":{"items":[{"text":"REFERRED","other":1},{"other":2}]}}}`,
+			[]string{"result.actions.items[].other", "result.newField", "result.status.extra"}, nil},
+		{"mismatch", `{"result":{"session":"2023","status":{"billCalNo":"12"},"vetoMessages":{"items":[{"signedDate":{"year":2023}}]},"milestones":{"items":{}}}}`, nil,
+			[]string{"result.milestones.items: expected []verboseapi.BillStatus got object", "result.session: expected int got string", "result.status.billCalNo: expected int got string", "result.vetoMessages.items[].signedDate: expected string got object"}},
+		{"embedded", `{"result":{"amendments":{"items":{"":{"basePrintNo":"S1234","sameAs":{"items":[{"printNo":"A1","bogus":true}]}}}}}}`,
+			[]string{"result.amendments.items.*.sameAs.items[].bogus"}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := schemaDrift("GetBill", []byte(tc.body), &BillResponse{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(d.UnknownFields, tc.unknown) {
+				t.Errorf("got unknown fields %q expected %q", d.UnknownFields, tc.unknown)
+			}
+			if !reflect.DeepEqual(d.TypeMismatches, tc.mismatch) {
+				t.Errorf("got type mismatches %q expected %q", d.TypeMismatches, tc.mismatch)
+			}
+		})
+	}
+}
+
+func TestDriftReport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3/bills/2023/S1234":
+			w.Write([]byte(`{"success":true,"result":{"basePrintNo":"S1234","session":2023,"newField":true}}`))
+		default:
+			w.Write([]byte(`{"success":true,"result":{"items":[]}}`))
+		}
+	}))
+	defer ts.Close()
+
+	a := NewAPI("token")
+	a.BaseURL = ts.URL
+	a.Limiter = rate.NewLimiter(rate.Inf, 1)
+	ctx := context.Background()
+
+	if _, err := a.GetBill(ctx, "2023", "S1234"); err != nil {
+		t.Fatal(err)
+	}
+	if r := a.DriftReport(); len(r) != 0 {
+		t.Fatalf("expected no report without Strict got %#v", r)
+	}
+
+	a.Strict = true
+	for i := 0; i < 2; i++ {
+		b, err := a.GetBill(ctx, "2023", "S1234")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.BasePrintNo != "S1234" {
+			t.Errorf("got %q", b.BasePrintNo)
+		}
+	}
+	if _, err := a.GetCalendars(ctx, 2023, 0); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]EndpointDrift{
+		"GetBill":      {Responses: 2, Drifted: 2, UnknownFields: map[string]int{"result.newField": 2}, TypeMismatches: map[string]int{}},
+		"GetCalendars": {Responses: 1, UnknownFields: map[string]int{}, TypeMismatches: map[string]int{}},
+	}
+	if got := a.DriftReport(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v", got)
+	}
+}
@@ -0,0 +1,80 @@
+package verboseapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetApprovals returns the governor's approval memos for a year
+// https://legislation.nysenate.gov/static/docs/html/approvals.html
+func (a NYSenateAPI) GetApprovals(ctx context.Context, year, offset int) (*ApprovalsResponse, error) {
+	if year == 0 {
+		return nil, nil
+	}
+	params := &url.Values{"full": []string{"true"}, "limit": []string{"100"}}
+	if offset > 1 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	path := fmt.Sprintf("/api/3/approvals/%d", year)
+	a.logger().DebugContext(ctx, "approvals", "year", year, "offset", offset)
+	var data ApprovalsResponse
+	err := a.get(ctx, "GetApprovals", path, params, &data)
+	return &data, err
+}
+
+// GetVetoes returns the governor's veto messages for a year
+// https://legislation.nysenate.gov/static/docs/html/vetoes.html
+func (a NYSenateAPI) GetVetoes(ctx context.Context, year, offset int) (*VetoesResponse, error) {
+	if year == 0 {
+		return nil, nil
+	}
+	params := &url.Values{"full": []string{"true"}, "limit": []string{"100"}}
+	if offset > 1 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	path := fmt.Sprintf("/api/3/vetoes/%d", year)
+	a.logger().DebugContext(ctx, "vetoes", "year", year, "offset", offset)
+	var data VetoesResponse
+	err := a.get(ctx, "GetVetoes", path, params, &data)
+	return &data, err
+}
+
+type ApprovalsResponse struct {
+	Envelope
+	Result struct {
+		Items []ApprovalMessage `json:"items"`
+		Size  int               `json:"size"`
+	} `json:"result"`
+}
+
+type VetoesResponse struct {
+	Envelope
+	Result struct {
+		Items []VetoMessage `json:"items"`
+		Size  int           `json:"size"`
+	} `json:"result"`
+}
+
+type ApprovalMessage struct {
+	BillID         BillID `json:"billId,omitempty"`
+	Year           int    `json:"year,omitempty"`
+	ApprovalNumber int    `json:"approvalNumber,omitempty"`
+	Chapter        int    `json:"chapter,omitempty"`
+	Signer         string `json:"signer,omitempty"`
+	Text           string `json:"text,omitempty"`
+}
+
+type VetoMessage struct {
+	BillID     BillID `json:"billId"`
+	Year       int    `json:"year"`
+	VetoNumber int    `json:"vetoNumber"`
+	MemoText   string `json:"memoText"`
+	VetoType   string `json:"vetoType"` // STANDARD, LINE_ITEM
+	Chapter    int    `json:"chapter"`
+	BillPage   int    `json:"billPage"`
+	LineStart  int    `json:"lineStart"`
+	LineEnd    int    `json:"lineEnd"`
+	Signer     string `json:"signer"`
+	SignedDate string `json:"signedDate"` // i.e. "2023-12-22"
+}
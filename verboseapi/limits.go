@@ -0,0 +1,187 @@
+package verboseapi
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Upstream hosts
+const (
+	OpenLegislationHost = "legislation.nysenate.gov"
+	AssemblyHost        = "nyassembly.gov"
+)
+
+// DefaultAssemblyLimit is the default request rate for nyassembly.gov
+var DefaultAssemblyLimit = rate.Every(2 * time.Second)
+
+// WaitStat is the time requests to a host spent waiting on the rate limiter
+type WaitStat struct {
+	Requests int64
+	// Delayed is the number of requests that had to wait for a token
+	Delayed int64
+	Waited  time.Duration
+	MaxWait time.Duration
+}
+
+type waitStats struct {
+	sync.Mutex
+	hosts map[string]*WaitStat
+}
+
+func (s *waitStats) record(host string, d time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	if s.hosts == nil {
+		s.hosts = make(map[string]*WaitStat)
+	}
+	w, ok := s.hosts[host]
+	if !ok {
+		w = &WaitStat{}
+		s.hosts[host] = w
+	}
+	w.Requests++
+	// Wait returns immediately when a token is available
+	if d >= time.Millisecond {
+		w.Delayed++
+		w.Waited += d
+	}
+	if d > w.MaxWait {
+		w.MaxWait = d
+	}
+}
+
+// WaitStats returns rate limiter wait times by host
+func (a NYSenateAPI) WaitStats() map[string]WaitStat {
+	o := make(map[string]WaitStat)
+	if a.stats == nil {
+		return o
+	}
+	a.stats.Lock()
+	defer a.stats.Unlock()
+	for host, w := range a.stats.hosts {
+		o[host] = *w
+	}
+	return o
+}
+
+// limiter returns the rate limiter for a host
+func (a NYSenateAPI) limiter(host string) *rate.Limiter {
+	if strings.TrimPrefix(host, "www.") == AssemblyHost && a.AssemblyLimiter != nil {
+		return a.AssemblyLimiter
+	}
+	return a.Limiter
+}
+
+func hostOf(u string) string {
+	if p, err := url.Parse(u); err == nil && p.Host != "" {
+		return strings.TrimPrefix(p.Host, "www.")
+	}
+	return OpenLegislationHost
+}
+
+// observe passes the response for u to the AdaptiveLimiter for its host
+func (a NYSenateAPI) observe(u string, resp *http.Response, latency time.Duration) {
+	adaptive := a.Adaptive
+	if hostOf(u) == AssemblyHost {
+		adaptive = a.AssemblyAdaptive
+	}
+	if adaptive != nil {
+		adaptive.Observe(resp, latency)
+	}
+}
+
+// wait blocks until the rate limiter for the host of u allows a request
+func (a NYSenateAPI) wait(ctx context.Context, u string) error {
+	host := hostOf(u)
+	if host == AssemblyHost && a.RespectRobots && a.robots != nil {
+		a.robots.Do(func() { a.applyCrawlDelay(ctx, "https://"+AssemblyHost+"/robots.txt") })
+	}
+	start := time.Now()
+	err := a.limiter(host).Wait(ctx)
+	if a.stats != nil {
+		a.stats.record(host, time.Since(start))
+	}
+	return err
+}
+
+// applyCrawlDelay slows AssemblyLimiter to the robots.txt Crawl-delay
+func (a NYSenateAPI) applyCrawlDelay(ctx context.Context, robotsURL string) {
+	l := a.limiter(AssemblyHost)
+	if err := l.Wait(ctx); err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", a.UserAgent)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		a.logger().WarnContext(ctx, "unable to fetch robots.txt", "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+	delay := parseCrawlDelay(resp.Body, a.UserAgent)
+	if delay > 0 && rate.Every(delay) < l.Limit() {
+		a.logger().DebugContext(ctx, "applying robots.txt crawl delay", "crawl_delay", delay)
+		l.SetLimit(rate.Every(delay))
+	}
+}
+
+// parseCrawlDelay returns the Crawl-delay from a robots.txt for userAgent (or "*")
+func parseCrawlDelay(r io.Reader, userAgent string) time.Duration {
+	var delay, wildcard time.Duration
+	var agents []string
+	inRules := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			// consecutive user-agent lines share a group
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "crawl-delay":
+			inRules = true
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds <= 0 {
+				continue
+			}
+			d := time.Duration(seconds * float64(time.Second))
+			for _, agent := range agents {
+				switch {
+				case agent == "*":
+					wildcard = d
+				case userAgent != "" && strings.Contains(strings.ToLower(userAgent), agent):
+					delay = d
+				}
+			}
+		default:
+			inRules = true
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+	return wildcard
+}
@@ -0,0 +1,100 @@
+package verboseapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestParseCrawlDelay(t *testing.T) {
+	type testCase struct {
+		name      string
+		robots    string
+		userAgent string
+		expected  time.Duration
+	}
+	tests := []testCase{
+		{"none", "User-agent: *\nDisallow: /cgi-bin/\n", "", 0},
+		{"wildcard", "User-agent: *\nCrawl-delay: 10\nDisallow: /cgi-bin/\n", "", 10 * time.Second},
+		{"fractional", "User-agent: *\nCrawl-delay: 0.5\n", "", 500 * time.Millisecond},
+		{"specific agent", "User-agent: *\nCrawl-delay: 10\n\nUser-agent: nysenateapi\nCrawl-delay: 3\n", "https://github.com/jehiah/nysenateapi", 3 * time.Second},
+		{"other agent", "User-agent: googlebot\nCrawl-delay: 1\n\nUser-agent: *\nCrawl-delay: 5 # comment\n", "https://github.com/jehiah/nysenateapi", 5 * time.Second},
+		{"grouped agents", "User-agent: bingbot\nUser-agent: *\nCrawl-delay: 4\n", "", 4 * time.Second},
+		{"invalid", "User-agent: *\nCrawl-delay: soon\n", "", 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseCrawlDelay(strings.NewReader(tc.robots), tc.userAgent)
+			if got != tc.expected {
+				t.Errorf("got %s expected %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLimiterByHost(t *testing.T) {
+	a := NewAPI("token")
+	if a.limiter(AssemblyHost) != a.AssemblyLimiter {
+		t.Errorf("expected AssemblyLimiter for %s", AssemblyHost)
+	}
+	if a.limiter("www.nyassembly.gov") != a.AssemblyLimiter {
+		t.Errorf("expected AssemblyLimiter for www.nyassembly.gov")
+	}
+	if a.limiter(OpenLegislationHost) != a.Limiter {
+		t.Errorf("expected Limiter for %s", OpenLegislationHost)
+	}
+	if a.AssemblyLimiter.Limit() >= a.Limiter.Limit() {
+		t.Errorf("expected a slower limit for nyassembly.gov got %v", a.AssemblyLimiter.Limit())
+	}
+}
+
+func TestWaitStats(t *testing.T) {
+	a := NewAPI("token")
+	a.AssemblyLimiter = rate.NewLimiter(rate.Every(20*time.Millisecond), 1)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := a.wait(ctx, "https://nyassembly.gov/leg/?bn=A1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.wait(ctx, apiDomain+"/api/3/bills"); err != nil {
+		t.Fatal(err)
+	}
+	stats := a.WaitStats()
+	assembly := stats[AssemblyHost]
+	if assembly.Requests != 3 || assembly.Delayed != 2 {
+		t.Errorf("unexpected assembly stats %#v", assembly)
+	}
+	if assembly.Waited < 30*time.Millisecond || assembly.MaxWait < 15*time.Millisecond {
+		t.Errorf("expected waits got %#v", assembly)
+	}
+	if s := stats[OpenLegislationHost]; s.Requests != 1 || s.Delayed != 0 {
+		t.Errorf("unexpected openlegislation stats %#v", s)
+	}
+}
+
+func TestApplyCrawlDelay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nCrawl-delay: 10\n"))
+	}))
+	defer ts.Close()
+
+	a := NewAPI("token")
+	a.AssemblyLimiter = rate.NewLimiter(rate.Every(time.Millisecond), 1)
+	a.applyCrawlDelay(context.Background(), ts.URL+"/robots.txt")
+	if a.AssemblyLimiter.Limit() != rate.Every(10*time.Second) {
+		t.Errorf("got limit %v", a.AssemblyLimiter.Limit())
+	}
+
+	// a slower configured limit is kept
+	a.AssemblyLimiter = rate.NewLimiter(rate.Every(time.Minute), 1)
+	a.applyCrawlDelay(context.Background(), ts.URL+"/robots.txt")
+	if a.AssemblyLimiter.Limit() != rate.Every(time.Minute) {
+		t.Errorf("got limit %v", a.AssemblyLimiter.Limit())
+	}
+}
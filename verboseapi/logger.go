@@ -0,0 +1,100 @@
+package verboseapi
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logger returns a.Logger or slog.Default()
+func (a NYSenateAPI) logger() *slog.Logger {
+	if a.Logger == nil {
+		return slog.Default()
+	}
+	return a.Logger
+}
+
+// LogrusHandler is a slog.Handler that writes to a logrus logger. i.e. to keep existing logrus configuration
+//
+//	a.Logger = slog.New(verboseapi.NewLogrusHandler(logrus.StandardLogger()))
+type LogrusHandler struct {
+	logger *logrus.Logger
+	fields logrus.Fields
+	prefix string
+}
+
+func NewLogrusHandler(l *logrus.Logger) *LogrusHandler {
+	return &LogrusHandler{logger: l, fields: logrus.Fields{}}
+}
+
+func logrusLevel(l slog.Level) logrus.Level {
+	switch {
+	case l >= slog.LevelError:
+		return logrus.ErrorLevel
+	case l >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case l >= slog.LevelInfo:
+		return logrus.InfoLevel
+	case l >= slog.LevelDebug:
+		return logrus.DebugLevel
+	}
+	return logrus.TraceLevel
+}
+
+func (h *LogrusHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.logger.IsLevelEnabled(logrusLevel(l))
+}
+
+func (h *LogrusHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make(logrus.Fields, len(h.fields)+r.NumAttrs())
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		addField(fields, h.prefix, attr)
+		return true
+	})
+	entry := h.logger.WithContext(ctx).WithFields(fields)
+	if !r.Time.IsZero() {
+		entry = entry.WithTime(r.Time)
+	}
+	entry.Log(logrusLevel(r.Level), r.Message)
+	return nil
+}
+
+func (h *LogrusHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(logrus.Fields, len(h.fields)+len(attrs))
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	for _, attr := range attrs {
+		addField(fields, h.prefix, attr)
+	}
+	return &LogrusHandler{logger: h.logger, fields: fields, prefix: h.prefix}
+}
+
+func (h *LogrusHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &LogrusHandler{logger: h.logger, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+// addField adds attr to fields; group attributes are flattened to "group.key"
+func addField(fields logrus.Fields, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			addField(fields, prefix, a)
+		}
+		return
+	}
+	fields[prefix+attr.Key] = attr.Value.Any()
+}
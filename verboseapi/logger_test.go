@@ -0,0 +1,57 @@
+package verboseapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+func TestLogrusHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetLevel(logrus.InfoLevel)
+	l.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true, DisableColors: true})
+
+	logger := slog.New(NewLogrusHandler(l)).With("client", "test")
+	logger.Debug("hidden")
+	logger.WithGroup("bill").Info("looking up bill", "session", "2023", slog.Group("sponsor", "id", 1))
+	logger.Warn("unable to parse", "error", "bad date")
+
+	expected := `level=info msg="looking up bill" bill.session=2023 bill.sponsor.id=1 client=test
+level=warning msg="unable to parse" client=test error="bad date"
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("got\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	a := NewAPI("token")
+	a.BaseURL = ts.URL
+	a.Limiter = rate.NewLimiter(rate.Inf, 1)
+	a.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := a.GetMembers(context.Background(), "2023", SenateChamber); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "msg=GetMembers session=2023 chamber=senate") || !strings.Contains(got, "msg=NYSenateAPI.get") {
+		t.Errorf("got %s", got)
+	}
+	if strings.Contains(got, "key=token") {
+		t.Errorf("unexpected token in logs")
+	}
+}
@@ -0,0 +1,219 @@
+package verboseapi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reasons a member name was flagged when resolving nyassembly.gov names
+const (
+	MemberUnresolved    = "unresolved"
+	MemberAmbiguous     = "ambiguous"
+	MemberLowConfidence = "low confidence"
+)
+
+// Confidence of a MemberMatch
+const (
+	ExactMatch      = 1.0
+	NormalizedMatch = 0.9
+	PartialMatch    = 0.7
+	// AdjacentSessionPenalty is applied to matches against members of an adjacent session
+	AdjacentSessionPenalty = 0.8
+)
+
+// MemberMatch is the result of resolving a name to a member
+type MemberMatch struct {
+	Name string
+	// Member is the matched member; MemberID is 0 when the name could not be resolved
+	Member     MemberEntry
+	Confidence float64
+	// Adjacent is set when the member was matched from an adjacent session
+	Adjacent   bool
+	Candidates []MemberEntry
+}
+
+// Resolved reports if the name matched a single member
+func (m MemberMatch) Resolved() bool {
+	return m.Member.MemberID > 0
+}
+
+// MemberWarning is a name on a vote that was not resolved to a member with full confidence
+type MemberWarning struct {
+	Name       string        `json:"name"`
+	Vote       string        `json:"vote"`
+	Reason     string        `json:"reason"` // MemberUnresolved, MemberAmbiguous, MemberLowConfidence
+	Confidence float64       `json:"confidence"`
+	MemberID   int           `json:"memberId,omitempty"`
+	Candidates []MemberEntry `json:"candidates,omitempty"`
+}
+
+// Warning returns a MemberWarning unless the match was exact or normalized in the current session
+func (m MemberMatch) Warning(vote string) (MemberWarning, bool) {
+	w := MemberWarning{
+		Name:       m.Name,
+		Vote:       vote,
+		Confidence: m.Confidence,
+		MemberID:   m.Member.MemberID,
+		Candidates: m.Candidates,
+	}
+	switch {
+	case !m.Resolved() && len(m.Candidates) > 1:
+		w.Reason = MemberAmbiguous
+	case !m.Resolved():
+		w.Reason = MemberUnresolved
+	case m.Confidence < NormalizedMatch:
+		w.Reason = MemberLowConfidence
+	default:
+		return MemberWarning{}, false
+	}
+	return w, true
+}
+
+// MemberResolver matches names as listed on nyassembly.gov ("Rosenthal L", "Jean-Pierre", "Jones Jr.")
+// to members. Members from adjacent sessions are used when there is no match in the current session.
+type MemberResolver struct {
+	members  []MemberEntry
+	adjacent []MemberEntry
+}
+
+func NewMemberResolver(members []MemberEntry, adjacent ...[]MemberEntry) *MemberResolver {
+	r := &MemberResolver{members: members}
+	for _, a := range adjacent {
+		r.adjacent = append(r.adjacent, a...)
+	}
+	return r
+}
+
+// Resolve matches a name to a member. A nil resolver has no members and matches nothing.
+func (r *MemberResolver) Resolve(name string) MemberMatch {
+	if r == nil {
+		return resolveMember(name, nil)
+	}
+	m := resolveMember(name, r.members)
+	if m.Resolved() || len(m.Candidates) > 1 || len(r.adjacent) == 0 {
+		return m
+	}
+	a := resolveMember(name, r.adjacent)
+	if len(a.Candidates) == 0 {
+		return m
+	}
+	a.Confidence *= AdjacentSessionPenalty
+	a.Adjacent = true
+	return a
+}
+
+var nameSuffixes = map[string]bool{"JR": true, "SR": true, "II": true, "III": true, "IV": true}
+
+// nameTokens splits a name into upper case words without punctuation or suffixes
+func nameTokens(s string) []string {
+	var o []string
+	for _, t := range strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ','
+	}) {
+		t = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) {
+				return r
+			}
+			return -1
+		}, t)
+		if t == "" || nameSuffixes[t] {
+			continue
+		}
+		o = append(o, t)
+	}
+	return o
+}
+
+// normalizeName removes case, punctuation, spacing and suffixes i.e. "De La Rosa" and "DELAROSA"
+func normalizeName(s string) string {
+	return strings.Join(nameTokens(s), "")
+}
+
+// uniqueMembers returns one entry per MemberID
+func uniqueMembers(members []MemberEntry) []MemberEntry {
+	var o []MemberEntry
+	seen := make(map[int]bool)
+	for _, m := range members {
+		if seen[m.MemberID] {
+			continue
+		}
+		seen[m.MemberID] = true
+		o = append(o, m)
+	}
+	sort.SliceStable(o, func(i, j int) bool { return o[i].MemberID < o[j].MemberID })
+	return o
+}
+
+func resolveMember(name string, members []MemberEntry) MemberMatch {
+	match := MemberMatch{Name: name}
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	if upper == "" {
+		return match
+	}
+	try := func(confidence float64, f func(m MemberEntry) bool) bool {
+		var c []MemberEntry
+		for _, m := range members {
+			if f(m) {
+				c = append(c, m)
+			}
+		}
+		c = uniqueMembers(c)
+		switch len(c) {
+		case 0:
+			return false
+		case 1:
+			match.Member = c[0]
+			match.Confidence = confidence
+		}
+		match.Candidates = c
+		return true
+	}
+
+	if try(ExactMatch, func(m MemberEntry) bool { return m.ShortName == upper }) {
+		return match
+	}
+	key := normalizeName(name)
+	if try(NormalizedMatch, func(m MemberEntry) bool { return normalizeName(m.ShortName) == key }) {
+		return match
+	}
+
+	// partial matches: every surname in name is a word in the member's short or full name
+	// and any initials match the member's first name
+	var surnames, initials []string
+	for _, t := range nameTokens(name) {
+		if len(t) == 1 {
+			initials = append(initials, t)
+		} else {
+			surnames = append(surnames, t)
+		}
+	}
+	if len(surnames) == 0 {
+		return match
+	}
+	try(PartialMatch, func(m MemberEntry) bool {
+		words := make(map[string]bool)
+		for _, t := range nameTokens(m.ShortName) {
+			words[t] = true
+		}
+		full := nameTokens(m.FullName)
+		for i, t := range full {
+			// skip the first name
+			if i > 0 || len(full) == 1 {
+				words[t] = true
+			}
+		}
+		for _, s := range surnames {
+			if !words[s] {
+				return false
+			}
+		}
+		for _, i := range initials {
+			if len(full) == 0 || !strings.HasPrefix(full[0], i) {
+				return false
+			}
+		}
+		return true
+	})
+	return match
+}
@@ -0,0 +1,139 @@
+package verboseapi
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestMemberResolver(t *testing.T) {
+	members := []MemberEntry{
+		{MemberID: 1, ShortName: "ROSENTHAL L", FullName: "Linda B. Rosenthal"},
+		{MemberID: 2, ShortName: "ROSENTHAL D", FullName: "Daniel Rosenthal"},
+		{MemberID: 3, ShortName: "JEAN-PIERRE", FullName: "Kimberly Jean-Pierre"},
+		{MemberID: 4, ShortName: "BICHOTTE HERMELYN", FullName: "Rodneyse Bichotte Hermelyn"},
+		// alternate short name earlier in the session
+		{MemberID: 4, ShortName: "BICHOTTE", FullName: "Rodneyse Bichotte Hermelyn"},
+		{MemberID: 5, ShortName: "O'DONNELL", FullName: "Daniel J. O'Donnell"},
+		{MemberID: 6, ShortName: "DE LOS SANTOS", FullName: "Manny De Los Santos"},
+		{MemberID: 7, ShortName: "SMITH", FullName: "Doug Smith"},
+		{MemberID: 8, ShortName: "WALKER", FullName: "Latrice Walker"},
+	}
+	previous := []MemberEntry{
+		{MemberID: 100, ShortName: "ABINANTI", FullName: "Thomas J. Abinanti"},
+		{MemberID: 8, ShortName: "WALKER", FullName: "Latrice Walker"},
+	}
+	r := NewMemberResolver(members, previous)
+
+	type testCase struct {
+		name       string
+		id         int
+		confidence float64
+		adjacent   bool
+		candidates int
+		reason     string
+	}
+	tests := []testCase{
+		{name: "Rosenthal L", id: 1, confidence: ExactMatch, candidates: 1},
+		{name: "Jean-Pierre", id: 3, confidence: ExactMatch, candidates: 1},
+		{name: "Jean Pierre", id: 3, confidence: NormalizedMatch, candidates: 1},
+		{name: "O'Donnell", id: 5, confidence: ExactMatch, candidates: 1},
+		{name: "ODonnell", id: 5, confidence: NormalizedMatch, candidates: 1},
+		{name: "DeLosSantos", id: 6, confidence: NormalizedMatch, candidates: 1},
+		{name: "Smith Jr.", id: 7, confidence: NormalizedMatch, candidates: 1},
+		{name: "Bichotte", id: 4, confidence: ExactMatch, candidates: 1},
+		{name: "Hermelyn", id: 4, confidence: PartialMatch, candidates: 1, reason: MemberLowConfidence},
+		{name: "Rosenthal", candidates: 2, reason: MemberAmbiguous},
+		{name: "Abinanti", id: 100, confidence: ExactMatch * AdjacentSessionPenalty, adjacent: true, candidates: 1, reason: MemberLowConfidence},
+		{name: "Nobody", reason: MemberUnresolved},
+		{name: "", reason: MemberUnresolved},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := r.Resolve(tc.name)
+			if m.Member.MemberID != tc.id {
+				t.Errorf("got member %d expected %d", m.Member.MemberID, tc.id)
+			}
+			if m.Confidence != tc.confidence {
+				t.Errorf("got confidence %v expected %v", m.Confidence, tc.confidence)
+			}
+			if m.Adjacent != tc.adjacent {
+				t.Errorf("got adjacent %v expected %v", m.Adjacent, tc.adjacent)
+			}
+			if len(m.Candidates) != tc.candidates {
+				t.Errorf("got %d candidates expected %d %#v", len(m.Candidates), tc.candidates, m.Candidates)
+			}
+			w, ok := m.Warning("Y")
+			if ok != (tc.reason != "") || w.Reason != tc.reason {
+				t.Errorf("got warning %v %q expected %q", ok, w.Reason, tc.reason)
+			}
+		})
+	}
+}
+
+func TestMemberResolverNil(t *testing.T) {
+	var r *MemberResolver
+	m := r.Resolve("Rosenthal L")
+	if m.Resolved() || len(m.Candidates) != 0 {
+		t.Errorf("got %#v", m)
+	}
+	if w, ok := m.Warning("Y"); !ok || w.Reason != MemberUnresolved {
+		t.Errorf("got warning %v %q", ok, w.Reason)
+	}
+}
+
+func TestParseAssemblyVotesWarnings(t *testing.T) {
+	body := `<table><caption><span>DATE:</span><span>06/02/2022</span></caption>
+<tr><td>Rosenthal L</td><td>Y</td><td>Rosenthal</td><td>NO</td></tr>
+<tr><td>Nobody</td><td>ER</td><td>Jean Pierre</td><td>Y</td></tr>
+</table>`
+	r := NewMemberResolver([]MemberEntry{
+		{MemberID: 1, ShortName: "ROSENTHAL L", FullName: "Linda B. Rosenthal"},
+		{MemberID: 2, ShortName: "ROSENTHAL D", FullName: "Daniel Rosenthal"},
+		{MemberID: 3, ShortName: "JEAN-PIERRE", FullName: "Kimberly Jean-Pierre"},
+	})
+	votes, err := parseAssemblyVotes(strings.NewReader(body), r, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 {
+		t.Fatalf("got %d votes", len(votes))
+	}
+	v := votes[0]
+	if got := v.MemberVotes.Items.Aye.Items; len(got) != 2 || got[0].MemberID != 1 || got[1].MemberID != 3 {
+		t.Errorf("unexpected ayes %#v", got)
+	}
+	if len(v.Warnings) != 2 {
+		t.Fatalf("got warnings %#v", v.Warnings)
+	}
+	if v.Warnings[0].Name != "Rosenthal" || v.Warnings[0].Reason != MemberAmbiguous || v.Warnings[0].Vote != "NO" {
+		t.Errorf("unexpected warning %#v", v.Warnings[0])
+	}
+	if v.Warnings[1].Name != "Nobody" || v.Warnings[1].Reason != MemberUnresolved {
+		t.Errorf("unexpected warning %#v", v.Warnings[1])
+	}
+}
+
+func TestParseAssemblyVotesUnknownCode(t *testing.T) {
+	body := `<table><caption><span>DATE:</span><span>06/02/2022</span></caption>
+<tr><td>Rosenthal L</td><td>Y</td><td>Jean-Pierre</td><td>NV</td></tr>
+</table>`
+	r := NewMemberResolver([]MemberEntry{
+		{MemberID: 1, ShortName: "ROSENTHAL L", FullName: "Linda B. Rosenthal"},
+		{MemberID: 3, ShortName: "JEAN-PIERRE", FullName: "Kimberly Jean-Pierre"},
+	})
+	votes, err := parseAssemblyVotes(strings.NewReader(body), r, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 {
+		t.Fatalf("got %d votes", len(votes))
+	}
+	mv := votes[0].MemberVotes.Items
+	if len(mv.Aye.Items) != 1 {
+		t.Errorf("unexpected ayes %#v", mv.Aye.Items)
+	}
+	if got := mv.Other["NV"].Items; len(got) != 1 || got[0].MemberID != 3 {
+		t.Errorf("unexpected other votes %#v", mv.Other)
+	}
+}
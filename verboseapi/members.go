@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/url"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // Note: response might have duplicates
@@ -13,12 +11,12 @@ func (a NYSenateAPI) GetMembers(ctx context.Context, session string, c Chamber)
 	if session == "" || c == "" {
 		return nil, nil
 	}
-	log.WithContext(ctx).WithField("session", session).WithField("chamber", c).Debugf("GetMembers session:%s", session)
+	a.logger().DebugContext(ctx, "GetMembers", "session", session, "chamber", c)
 	path := fmt.Sprintf("/api/3/members/%s/%s", url.PathEscape(session), url.PathEscape(string(c)))
 	// senate is 63, assembly is 150
 	params := &url.Values{"full": []string{"true"}, "limit": []string{"200"}}
 	var data MemberListResponse
-	err := a.get(ctx, path, params, &data)
+	err := a.get(ctx, "GetMembers", path, params, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -101,14 +99,14 @@ type MemberEntryList struct {
 }
 
 type Person struct {
-	PersonID   int         `json:"personId"`
-	FullName   string      `json:"fullName"`
-	FirstName  string      `json:"firstName"`
-	MiddleName string      `json:"middleName"`
-	LastName   string      `json:"lastName"`
-	Email      string      `json:"email"`
-	Prefix     string      `json:"prefix"`
-	Suffix     interface{} `json:"suffix"`
-	Verified   bool        `json:"verified"`
-	ImgName    string      `json:"imgName"`
+	PersonID   int    `json:"personId"`
+	FullName   string `json:"fullName"`
+	FirstName  string `json:"firstName"`
+	MiddleName string `json:"middleName"`
+	LastName   string `json:"lastName"`
+	Email      string `json:"email"`
+	Prefix     string `json:"prefix"`
+	Suffix     string `json:"suffix"`
+	Verified   bool   `json:"verified"`
+	ImgName    string `json:"imgName"`
 }
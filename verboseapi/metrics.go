@@ -0,0 +1,51 @@
+package verboseapi
+
+import (
+	"context"
+	"strconv"
+)
+
+// MetricLabels are the label names for the label values passed to MetricsObserver
+var MetricLabels = []string{"endpoint", "host", "code"}
+
+// MetricsObserver is an Observer that records Prometheus style counters and histograms. Each func is called
+// with label values in the order of MetricLabels and may be nil. i.e. with github.com/prometheus/client_golang
+//
+//	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "nysenateapi_requests_total"}, verboseapi.MetricLabels)
+//	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "nysenateapi_request_duration_seconds"}, verboseapi.MetricLabels)
+//	a.Observer = verboseapi.MetricsObserver{
+//		Requests: func(labels ...string) { requests.WithLabelValues(labels...).Inc() },
+//		Duration: func(seconds float64, labels ...string) { duration.WithLabelValues(labels...).Observe(seconds) },
+//	}
+type MetricsObserver struct {
+	// Requests and Errors are counters incremented for each request
+	Requests func(labels ...string)
+	Errors   func(labels ...string)
+	// Duration and Bytes are histograms of the response time and response body size
+	Duration func(seconds float64, labels ...string)
+	Bytes    func(bytes float64, labels ...string)
+}
+
+func (m MetricsObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+
+func (m MetricsObserver) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	code := "error"
+	if result.StatusCode != 0 {
+		code = strconv.Itoa(result.StatusCode)
+	}
+	labels := []string{info.Endpoint, info.Host, code}
+	if m.Requests != nil {
+		m.Requests(labels...)
+	}
+	if m.Errors != nil && result.Err != nil {
+		m.Errors(labels...)
+	}
+	if m.Duration != nil {
+		m.Duration(result.Duration.Seconds(), labels...)
+	}
+	if m.Bytes != nil {
+		m.Bytes(float64(result.Bytes), labels...)
+	}
+}
@@ -0,0 +1,267 @@
+package verboseapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// AssemblyBill is the information on a nyassembly.gov bill page
+type AssemblyBill struct {
+	PrintNo       string
+	Session       string
+	SameAs        string
+	Sponsor       string
+	CoSponsors    []string
+	MultiSponsors []string
+	LawSection    string
+	Title         string
+	Summary       string
+	Actions       []BillAction
+	Committees    []PastCommittee
+	Memo          string
+	Text          string
+	Videos        []AssemblyVideo
+	Votes         []BillVote
+}
+
+type AssemblyVideo struct {
+	Title string
+	URL   string
+}
+
+// AssemblyBill returns the summary, actions, memo, text, floor video and votes for a bill from nyassembly.gov.
+// Votes are matched against members and are skipped when members is nil.
+//
+// https://nyassembly.gov/leg/?default_fld=&leg_video=Y&bn=A09275&term=2021&Summary=Y&Actions=Y&Memo=Y&Text=Y&Committee%26nbspVotes=Y&Floor%26nbspVotes=Y
+func (a NYSenateAPI) AssemblyBill(ctx context.Context, members []MemberEntry, session, printNo string) (*AssemblyBill, error) {
+	u := "https://nyassembly.gov/leg/?" + url.Values{
+		"default_fld":         []string{""},
+		"leg_video":           []string{"Y"},
+		"bn":                  []string{assemblyBillNo(printNo)},
+		"term":                []string{session},
+		"Summary":             []string{"Y"},
+		"Actions":             []string{"Y"},
+		"Memo":                []string{"Y"},
+		"Text":                []string{"Y"},
+		"Committee&nbspVotes": []string{"Y"},
+		"Floor&nbspVotes":     []string{"Y"},
+	}.Encode()
+	body, err := a.getHTML(ctx, "AssemblyBill", u)
+	if err != nil {
+		return nil, err
+	}
+
+	bill, err := parseAssemblyBill(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	bill.Session = session
+	if bill.PrintNo == "" {
+		bill.PrintNo = printNo
+	}
+	if members != nil {
+		bill.Votes, err = parseAssemblyVotes(bytes.NewReader(body), NewMemberResolver(members), a.logger())
+	}
+	a.logger().DebugContext(ctx, "looking up NYAssembly bill", "session", session, "printNo", printNo, "nyassembly", u, "actions", len(bill.Actions), "votes", len(bill.Votes))
+	return bill, err
+}
+
+var (
+	// i.e. "A09275 Actions:", "Floor Votes:", "Memo"
+	assemblySectionPattern = regexp.MustCompile(`(?i)^(?:[AS]\d+[A-Z]?\s+)?(Summary|Actions|Floor Votes|Committee Votes|Memo|Text)\s*:?$`)
+	assemblyDatePattern    = regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`)
+	// i.e. "referred to codes", "reported referred to ways and means", "amend (t) and recommit to codes", "COMMITTED TO RULES"
+	assemblyReferral   = regexp.MustCompile(`(?i)^(?:reported\s+)?(?:referred|committed|recommitted|amend(?:\s*\(t\))?\s+and\s+recommit)\s+to\s+(.+)$`)
+	assemblyLawSection = regexp.MustCompile(`^(Amd|Add|Rpld|Rpldd|Ren)\b`)
+	// i.e. "No Same As", "No Memo available"
+	assemblyMissing = regexp.MustCompile(`(?i)^no (same as|memo|text)\b`)
+)
+
+func parseAssemblyBill(r io.Reader) (*AssemblyBill, error) {
+	b := &AssemblyBill{}
+	z := html.NewTokenizer(r)
+	var section, cell, pre, linkHref, linkText string
+	var cells []string
+	var inCell, inPre, inLink bool
+	var memo, text []string
+
+	for {
+		tt := z.Next()
+		token := z.Token()
+		switch tt {
+		case html.ErrorToken:
+			err := z.Err()
+			if err == io.EOF {
+				err = nil
+			}
+			b.Memo = strings.Join(memo, "\n")
+			b.Text = strings.Join(text, "\n")
+			return b, err
+		case html.TextToken:
+			switch {
+			case inPre:
+				pre += token.Data
+				continue
+			case inLink:
+				linkText += token.Data
+			}
+			t := strings.TrimSpace(token.Data)
+			if m := assemblySectionPattern.FindStringSubmatch(t); m != nil && !inCell {
+				section = strings.ToLower(m[1])
+				continue
+			}
+			if inCell {
+				if cell != "" && t != "" {
+					cell += " "
+				}
+				cell += t
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "tr":
+				cells = nil
+			case "td", "th":
+				inCell = true
+				cell = ""
+			case "pre":
+				inPre = true
+				pre = ""
+			case "br":
+				if inPre {
+					pre += "\n"
+				}
+			case "a":
+				for _, attr := range token.Attr {
+					if attr.Key == "href" && strings.Contains(strings.ToLower(attr.Val), "video") {
+						inLink = true
+						linkHref = attr.Val
+						linkText = ""
+					}
+				}
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "td", "th":
+				inCell = false
+				cells = append(cells, cell)
+			case "tr":
+				b.addRow(section, cells)
+				cells = nil
+			case "pre":
+				inPre = false
+				switch section {
+				case "memo", "text":
+					pre = strings.Trim(pre, "\n")
+					if assemblyMissing.MatchString(strings.TrimSpace(pre)) {
+						break
+					}
+					if section == "memo" {
+						memo = append(memo, pre)
+					} else {
+						text = append(text, pre)
+					}
+				}
+			case "a":
+				if inLink {
+					b.Videos = append(b.Videos, AssemblyVideo{
+						Title: strings.Join(strings.Fields(linkText), " "),
+						URL:   linkHref,
+					})
+				}
+				inLink = false
+			}
+		}
+	}
+}
+
+func (b *AssemblyBill) addRow(section string, cells []string) {
+	switch section {
+	case "summary":
+		b.addSummary(cells)
+	case "actions":
+		if len(cells) < 2 || !assemblyDatePattern.MatchString(cells[0]) {
+			return
+		}
+		dt, err := time.Parse("01/02/2006", cells[0])
+		if err != nil {
+			return
+		}
+		action := BillAction{
+			Date:       dt.Format("2006-01-02"),
+			Text:       cells[1],
+			Chamber:    "ASSEMBLY",
+			SequenceNo: len(b.Actions) + 1,
+		}
+		// Senate actions are listed in upper case
+		if strings.ToUpper(action.Text) == action.Text {
+			action.Chamber = "SENATE"
+		}
+		b.Actions = append(b.Actions, action)
+		if m := assemblyReferral.FindStringSubmatch(action.Text); m != nil {
+			b.Committees = append(b.Committees, PastCommittee{
+				Chamber:       action.Chamber,
+				Name:          strings.ToUpper(m[1]),
+				ReferenceDate: action.Date,
+			})
+		}
+	}
+}
+
+func splitNames(s string) []string {
+	var o []string
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			o = append(o, n)
+		}
+	}
+	return o
+}
+
+func (b *AssemblyBill) addSummary(cells []string) {
+	var key, value string
+	switch len(cells) {
+	case 0:
+		return
+	case 1:
+		value = cells[0]
+	default:
+		key, value = strings.ToUpper(strings.TrimSuffix(cells[0], ":")), cells[1]
+	}
+	switch key {
+	case "BILL NO":
+		b.PrintNo = value
+	case "SAME AS":
+		// "SAME AS S07710-A" or "No Same As"
+		if !assemblyMissing.MatchString(value) {
+			b.SameAs = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(value), "SAME AS"))
+		}
+	case "SPONSOR":
+		b.Sponsor = value
+	case "COSPNSR":
+		b.CoSponsors = splitNames(value)
+	case "MLTSPNSR":
+		b.MultiSponsors = splitNames(value)
+	case "":
+		if value == "" {
+			return
+		}
+		switch {
+		case b.LawSection == "" && assemblyLawSection.MatchString(value):
+			b.LawSection = value
+		case b.Title == "":
+			b.Title = value
+		default:
+			if b.Summary != "" {
+				b.Summary += "\n"
+			}
+			b.Summary += value
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package verboseapi
+
+import (
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAssemblyBill(t *testing.T) {
+	type testCase struct {
+		file          string
+		printNo       string
+		sameAs        string
+		sponsor       string
+		coSponsors    []string
+		lawSection    string
+		title         string
+		actions       int
+		chambers      []string
+		committees    []string
+		memoPrefix    string
+		textContains  string
+		videos        int
+		votes         int
+		voteCommittee string
+	}
+	tests := []testCase{
+		{
+			file:         "A09275-2021.html",
+			printNo:      "A09275A",
+			sameAs:       "S07710-A",
+			sponsor:      "Glick",
+			coSponsors:   []string{"Gottfried", "Epstein", "Simon"},
+			lawSection:   "Amd §27-0903, En Con L",
+			title:        "Establishes an inventory of sources of emissions in disadvantaged communities.",
+			actions:      7,
+			chambers:     []string{"ASSEMBLY", "ASSEMBLY", "ASSEMBLY", "ASSEMBLY", "ASSEMBLY", "ASSEMBLY", "SENATE"},
+			committees:   []string{"ENVIRONMENTAL CONSERVATION", "ENVIRONMENTAL CONSERVATION", "WAYS AND MEANS", "RULES"},
+			memoPrefix:   "NEW YORK STATE ASSEMBLY",
+			textContains: "Section 27-0903 of the environmental conservation law",
+			videos:       1,
+			votes:        1,
+		},
+		{
+			file:          "A06141-2023.html",
+			printNo:       "A06141",
+			sponsor:       "Rosenthal L",
+			lawSection:    "Add §399-zzzzz, Gen Bus L",
+			title:         "Relates to the sale of certain products.",
+			actions:       2,
+			chambers:      []string{"ASSEMBLY", "ASSEMBLY"},
+			committees:    []string{"CONSUMER AFFAIRS AND PROTECTION", "CONSUMER AFFAIRS AND PROTECTION"},
+			votes:         1,
+			voteCommittee: "Consumer Affairs and Protection",
+		},
+		{
+			file: "not-found.html",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			body, err := os.ReadFile("testdata/nyassembly/" + tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := parseAssemblyBill(strings.NewReader(string(body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.PrintNo != tc.printNo {
+				t.Errorf("PrintNo got %q expected %q", b.PrintNo, tc.printNo)
+			}
+			if b.SameAs != tc.sameAs {
+				t.Errorf("SameAs got %q expected %q", b.SameAs, tc.sameAs)
+			}
+			if b.Sponsor != tc.sponsor {
+				t.Errorf("Sponsor got %q expected %q", b.Sponsor, tc.sponsor)
+			}
+			if !reflect.DeepEqual(b.CoSponsors, tc.coSponsors) {
+				t.Errorf("CoSponsors got %q expected %q", b.CoSponsors, tc.coSponsors)
+			}
+			if b.LawSection != tc.lawSection {
+				t.Errorf("LawSection got %q expected %q", b.LawSection, tc.lawSection)
+			}
+			if b.Title != tc.title {
+				t.Errorf("Title got %q expected %q", b.Title, tc.title)
+			}
+			if len(b.Actions) != tc.actions {
+				t.Fatalf("got %d actions expected %d %#v", len(b.Actions), tc.actions, b.Actions)
+			}
+			for i, a := range b.Actions {
+				if a.Chamber != tc.chambers[i] {
+					t.Errorf("action[%d] %q chamber got %s expected %s", i, a.Text, a.Chamber, tc.chambers[i])
+				}
+				if a.SequenceNo != i+1 {
+					t.Errorf("action[%d] SequenceNo got %d", i, a.SequenceNo)
+				}
+			}
+			var committees []string
+			for _, c := range b.Committees {
+				committees = append(committees, c.Name)
+			}
+			if !reflect.DeepEqual(committees, tc.committees) {
+				t.Errorf("Committees got %q expected %q", committees, tc.committees)
+			}
+			if !strings.HasPrefix(b.Memo, tc.memoPrefix) || (tc.memoPrefix == "" && b.Memo != "") {
+				t.Errorf("Memo got %q expected prefix %q", b.Memo, tc.memoPrefix)
+			}
+			if !strings.Contains(b.Text, tc.textContains) || (tc.textContains == "" && b.Text != "") {
+				t.Errorf("Text got %q expected %q", b.Text, tc.textContains)
+			}
+			if len(b.Videos) != tc.videos {
+				t.Errorf("got %d videos expected %d", len(b.Videos), tc.videos)
+			}
+
+			votes, err := parseAssemblyVotes(strings.NewReader(string(body)), NewMemberResolver(nil), slog.Default())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(votes) != tc.votes {
+				t.Fatalf("got %d votes expected %d", len(votes), tc.votes)
+			}
+			if len(votes) > 0 && votes[0].Committee.Name != tc.voteCommittee {
+				t.Errorf("vote committee got %q expected %q", votes[0].Committee.Name, tc.voteCommittee)
+			}
+		})
+	}
+}
+
+func TestParseAssemblyBillVideo(t *testing.T) {
+	body, err := os.ReadFile("testdata/nyassembly/A09275-2021.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := parseAssemblyBill(strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := AssemblyVideo{
+		Title: "Floor Video June 2, 2022",
+		URL:   "https://nyassembly.gov/av/video/?bill=A09275&date=2022-06-02",
+	}
+	if len(b.Videos) != 1 || b.Videos[0] != expected {
+		t.Fatalf("got %#v expected %#v", b.Videos, expected)
+	}
+	if b.Actions[0].Date != "2022-03-01" {
+		t.Errorf("got date %q", b.Actions[0].Date)
+	}
+	if b.Committees[0].ReferenceDate != "2022-03-01" || b.Committees[0].Chamber != "ASSEMBLY" {
+		t.Errorf("got committee %#v", b.Committees[0])
+	}
+}
+
+func TestAssemblyReferral(t *testing.T) {
+	tests := map[string]string{
+		"referred to codes":                   "codes",
+		"reported referred to ways and means": "ways and means",
+		"amend (t) and recommit to codes":     "codes",
+		"amend and recommit to health":        "health",
+		"COMMITTED TO RULES":                  "RULES",
+		"reported":                            "",
+		"amended on third reading 6141a":      "",
+		"substituted by s1234":                "",
+	}
+	for action, expected := range tests {
+		var got string
+		if m := assemblyReferral.FindStringSubmatch(action); m != nil {
+			got = m[1]
+		}
+		if got != expected {
+			t.Errorf("%q got committee %q expected %q", action, got, expected)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package verboseapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// AssemblyMeeting is an Assembly committee meeting or public hearing from nyassembly.gov in the same
+// form as a Senate CommitteeAgenda (with a single addendum).
+type AssemblyMeeting struct {
+	CommitteeAgenda
+	Hearing bool   `json:"hearing,omitempty"`
+	Subject string `json:"subject,omitempty"`
+}
+
+// Meeting returns the meeting details
+func (m AssemblyMeeting) Meeting() Meeting {
+	if len(m.Addenda.Items) == 0 {
+		return Meeting{}
+	}
+	return m.Addenda.Items[0].Meeting
+}
+
+// AssemblyCommitteeAgendas returns upcoming Assembly committee meetings and the bills on their agendas
+//
+// https://nyassembly.gov/leg/?sh=agen
+func (a NYSenateAPI) AssemblyCommitteeAgendas(ctx context.Context) ([]AssemblyMeeting, error) {
+	u := "https://nyassembly.gov/leg/?sh=agen"
+	body, err := a.getHTML(ctx, "AssemblyCommitteeAgendas", u)
+	if err != nil {
+		return nil, err
+	}
+	meetings, err := parseAssemblyMeetings(bytes.NewReader(body), false)
+	a.logger().DebugContext(ctx, "looking up NYAssembly committee agendas", "nyassembly", u, "meetings", len(meetings))
+	return meetings, err
+}
+
+// AssemblyHearings returns upcoming Assembly public hearings
+//
+// https://nyassembly.gov/leg/?sh=hear
+func (a NYSenateAPI) AssemblyHearings(ctx context.Context) ([]AssemblyMeeting, error) {
+	u := "https://nyassembly.gov/leg/?sh=hear"
+	body, err := a.getHTML(ctx, "AssemblyHearings", u)
+	if err != nil {
+		return nil, err
+	}
+	meetings, err := parseAssemblyMeetings(bytes.NewReader(body), true)
+	a.logger().DebugContext(ctx, "looking up NYAssembly hearings", "nyassembly", u, "hearings", len(meetings))
+	return meetings, err
+}
+
+var (
+	// i.e. "Chair: Lavine", "Place:", "Committees: Codes; Judiciary"
+	assemblyLabelPattern = regexp.MustCompile(`(?i)^(Committees?|Chair|Chairs|Date|Time|Place|Location|Subject|Purpose|Notes?)\s*:\s*(.*)$`)
+	// i.e. "Tuesday, May 14, 2024"
+	assemblyLongDate = regexp.MustCompile(`(?:(?:Mon|Tues|Wednes|Thurs|Fri|Satur|Sun)day,?\s+)?((?:January|February|March|April|May|June|July|August|September|October|November|December)\s+\d{1,2},\s+\d{4})`)
+	// i.e. "10:30 AM", "1:00 p.m."
+	assemblyTime        = regexp.MustCompile(`(?i)\b(\d{1,2}):(\d{2})\s*([ap])\.?m\.?`)
+	assemblyBillPattern = regexp.MustCompile(`^([AS])0*(\d{1,5})([A-Z]?)$`)
+)
+
+// assemblyBillNo returns a print number zero padded as nyassembly.gov URLs expect i.e. "A6141" is "A06141"
+func assemblyBillNo(printNo string) string {
+	m := assemblyBillPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(printNo)))
+	if m == nil {
+		return printNo
+	}
+	return m[1] + strings.Repeat("0", 5-len(m[2])) + m[2] + m[3]
+}
+
+// assemblyMeetingBuilder accumulates the fields of a single meeting or hearing
+type assemblyMeetingBuilder struct {
+	heading                             string
+	committee, chair, location, subject string
+	notes                               []string
+	date                                time.Time
+	hour, minute                        int
+	hasTime                             bool
+	bills                               []BillID
+	seen                                map[string]bool
+}
+
+func (b *assemblyMeetingBuilder) set(label, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	switch strings.ToLower(label) {
+	case "committee", "committees":
+		b.committee = value
+	case "chair", "chairs":
+		b.chair = value
+	case "date":
+		b.setDate(value)
+	case "time":
+		b.setTime(value)
+	case "place", "location":
+		b.location = value
+	case "subject", "purpose":
+		if b.subject == "" {
+			b.subject = value
+		} else {
+			b.notes = append(b.notes, value)
+		}
+	case "note", "notes":
+		b.notes = append(b.notes, value)
+	}
+}
+
+func (b *assemblyMeetingBuilder) setDate(s string) bool {
+	m := assemblyLongDate.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	d, err := time.Parse("January 2, 2006", strings.Join(strings.Fields(m[1]), " "))
+	if err != nil {
+		return false
+	}
+	b.date = d
+	return true
+}
+
+func (b *assemblyMeetingBuilder) setTime(s string) bool {
+	m := assemblyTime.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	hour = hour % 12
+	if strings.EqualFold(m[3], "p") {
+		hour += 12
+	}
+	b.hour, b.minute, b.hasTime = hour, minute, true
+	return true
+}
+
+func (b *assemblyMeetingBuilder) addBill(s string) {
+	m := assemblyBillPattern.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil {
+		return
+	}
+	printNo := m[1] + m[2]
+	if b.seen == nil {
+		b.seen = make(map[string]bool)
+	}
+	if b.seen[printNo] {
+		return
+	}
+	b.seen[printNo] = true
+	b.bills = append(b.bills, BillID{BasePrintNo: printNo, PrintNo: printNo + m[3], Version: m[3]})
+}
+
+func (b *assemblyMeetingBuilder) meeting(hearing bool) (AssemblyMeeting, bool) {
+	if b.date.IsZero() {
+		return AssemblyMeeting{}, false
+	}
+	m := AssemblyMeeting{Hearing: hearing, Subject: b.subject}
+	committee := b.committee
+	switch {
+	case committee != "":
+	case hearing:
+		// hearings are titled by subject when the committees are listed separately
+		if m.Subject == "" {
+			m.Subject = b.heading
+		}
+	default:
+		// "Codes Committee Agenda"
+		committee = strings.TrimSpace(strings.TrimSuffix(b.heading, "Agenda"))
+		committee = strings.TrimSpace(strings.TrimSuffix(committee, "Committee"))
+	}
+	m.CommitteeID = CommitteeID{Chamber: "ASSEMBLY", Name: committee}
+
+	// meetings without a set time (i.e. "Time: Off the Floor") only have a date
+	dt, layout := b.date, "2006-01-02"
+	if b.hasTime {
+		dt = dt.Add(time.Duration(b.hour)*time.Hour + time.Duration(b.minute)*time.Minute)
+		layout = "2006-01-02T15:04"
+	}
+	// sessions start in odd years
+	session := dt.Year() - (1 - dt.Year()%2)
+	var addendum CommitteeAgendaAddendum
+	addendum.Meeting = Meeting{
+		Chair:           b.chair,
+		Location:        b.location,
+		MeetingDateTime: dt.Format(layout),
+		Notes:           strings.Join(b.notes, "\n"),
+	}
+	for _, bill := range b.bills {
+		bill.Session = session
+		addendum.Bills.Items = append(addendum.Bills.Items, AgendaBill{BillID: bill})
+	}
+	addendum.Bills.Size = len(addendum.Bills.Items)
+	m.Addenda.Items = []CommitteeAgendaAddendum{addendum}
+	m.Addenda.Size = 1
+	return m, true
+}
+
+// parseAssemblyMeetings parses a nyassembly.gov agenda or hearing schedule. Each h2/h3/h4 heading starts a
+// new meeting; details are read from labeled text ("Chair:", "Place:", ...) or from unlabeled dates and times.
+// Bills are print numbers in links or table cells.
+func parseAssemblyMeetings(r io.Reader, hearing bool) ([]AssemblyMeeting, error) {
+	var out []AssemblyMeeting
+	var current *assemblyMeetingBuilder
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if m, ok := current.meeting(hearing); ok {
+			out = append(out, m)
+		}
+		current = nil
+	}
+
+	z := html.NewTokenizer(r)
+	var inHeading, inBillCell bool
+	var heading, label string
+	for {
+		tt := z.Next()
+		token := z.Token()
+		switch tt {
+		case html.ErrorToken:
+			err := z.Err()
+			if err == io.EOF {
+				err = nil
+			}
+			flush()
+			return out, err
+		case html.StartTagToken:
+			switch token.Data {
+			case "h2", "h3", "h4":
+				flush()
+				inHeading = true
+				heading = ""
+				label = ""
+			case "a", "td":
+				inBillCell = true
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "h2", "h3", "h4":
+				inHeading = false
+				current = &assemblyMeetingBuilder{heading: strings.Join(strings.Fields(heading), " ")}
+			case "a", "td":
+				inBillCell = false
+			}
+		case html.TextToken:
+			if inHeading {
+				heading += token.Data
+				continue
+			}
+			if current == nil {
+				continue
+			}
+			t := strings.TrimSpace(token.Data)
+			if t == "" {
+				continue
+			}
+			if inBillCell && assemblyBillPattern.MatchString(strings.ToUpper(t)) {
+				current.addBill(t)
+				continue
+			}
+			if m := assemblyLabelPattern.FindStringSubmatch(t); m != nil {
+				if strings.TrimSpace(m[2]) == "" {
+					// value follows in the next text i.e. <b>Chair:</b> Lavine
+					label = m[1]
+					continue
+				}
+				current.set(m[1], m[2])
+				label = ""
+				continue
+			}
+			if label != "" {
+				current.set(label, t)
+				label = ""
+				continue
+			}
+			// unlabeled "Tuesday, May 14, 2024 10:30 AM"
+			if current.date.IsZero() {
+				current.setDate(t)
+			}
+			if !current.hasTime {
+				current.setTime(t)
+			}
+		}
+	}
+}
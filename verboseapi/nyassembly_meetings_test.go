@@ -0,0 +1,118 @@
+package verboseapi
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseAssemblyMeetings(t *testing.T) {
+	type meeting struct {
+		Committee string
+		Chair     string
+		Location  string
+		Time      string
+		Notes     string
+		Subject   string
+		Bills     []string
+	}
+	type testCase struct {
+		file     string
+		hearing  bool
+		expected []meeting
+	}
+	tests := []testCase{
+		{
+			file: "agendas.html",
+			expected: []meeting{
+				{
+					Committee: "Codes",
+					Chair:     "Lavine",
+					Location:  "Room 830 LOB",
+					Time:      "2024-05-14T10:30",
+					Bills:     []string{"A6141-2023", "A9275A-2023"},
+				},
+				{
+					Committee: "Ways and Means",
+					Chair:     "Weinstein",
+					Location:  "Room 923 LOB",
+					Time:      "2024-05-15",
+					Notes:     "Meeting will be held off the floor",
+					Bills:     []string{"S1234-2023"},
+				},
+			},
+		},
+		{
+			file:    "hearings.html",
+			hearing: true,
+			expected: []meeting{
+				{
+					Committee: "Standing Committee on Energy; Standing Committee on Transportation",
+					Chair:     "Assemblymember Cusick, Assemblymember Magnarelli",
+					Location:  "Assembly Hearing Room, 250 Broadway, Room 1923, New York, NY",
+					Time:      "2024-10-08T10:00",
+					Subject:   "To examine the deployment of public charging.",
+				},
+				{
+					Committee: "Ways and Means",
+					Location:  "Hearing Room B, LOB, Albany",
+					Time:      "2025-01-16T13:30",
+					Subject:   "Implementation of the enacted budget",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			f, err := os.Open("testdata/nyassembly/" + tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			meetings, err := parseAssemblyMeetings(f, tc.hearing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []meeting
+			for _, m := range meetings {
+				if m.Hearing != tc.hearing {
+					t.Errorf("got Hearing %v", m.Hearing)
+				}
+				if m.CommitteeID.Chamber != "ASSEMBLY" {
+					t.Errorf("got chamber %q", m.CommitteeID.Chamber)
+				}
+				mm := m.Meeting()
+				g := meeting{
+					Committee: m.CommitteeID.Name,
+					Chair:     mm.Chair,
+					Location:  mm.Location,
+					Time:      mm.MeetingDateTime,
+					Notes:     mm.Notes,
+					Subject:   m.Subject,
+				}
+				for _, b := range m.Addenda.Items[0].Bills.Items {
+					g.Bills = append(g.Bills, fmt.Sprintf("%s-%d", b.BillID.PrintNo, b.BillID.Session))
+				}
+				got = append(got, g)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("got\n%#v\nexpected\n%#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAssemblyBillNo(t *testing.T) {
+	for printNo, expected := range map[string]string{
+		"A6141":   "A06141",
+		"a9275A":  "A09275A",
+		"S01234":  "S01234",
+		"A12345B": "A12345B",
+		"K123":    "K123",
+	} {
+		if got := assemblyBillNo(printNo); got != expected {
+			t.Errorf("assemblyBillNo(%q) got %q expected %q", printNo, got, expected)
+		}
+	}
+}
@@ -1,14 +1,14 @@
 package verboseapi
 
 import (
+	"bytes"
 	"context"
 	"io"
-	"net/http"
+	"log/slog"
 	"net/url"
 	"strings"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/html"
 )
 
@@ -18,43 +18,35 @@ import (
 //
 // https://nyassembly.gov/leg/?default_fld=&leg_video=&bn=A09275&term=2021&Committee%26nbspVotes=Y&Floor%26nbspVotes=Y
 func (a NYSenateAPI) AssemblyVotes(ctx context.Context, members []MemberEntry, session, printNo string) ([]BillVote, error) {
-	err := a.Limiter.Wait(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return a.ResolveAssemblyVotes(ctx, NewMemberResolver(members), session, printNo)
+}
+
+// ResolveAssemblyVotes returns votes for an assembly bill with member names matched by resolver.
+// Names that are not matched with full confidence are listed in BillVote.Warnings.
+func (a NYSenateAPI) ResolveAssemblyVotes(ctx context.Context, resolver *MemberResolver, session, printNo string) ([]BillVote, error) {
 	u := "https://nyassembly.gov/leg/?" + url.Values{
 		"default_fld":         []string{""},
 		"leg_video":           []string{""},
-		"bn":                  []string{printNo},
+		"bn":                  []string{assemblyBillNo(printNo)},
 		"term":                []string{session},
 		"Committee&nbspVotes": []string{"Y"},
 		"Floor&nbspVotes":     []string{"Y"},
 	}.Encode()
-	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", a.UserAgent)
-	resp, err := http.DefaultClient.Do(req)
+	body, err := a.getHTML(ctx, "AssemblyVotes", u)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	votes, err := parseAssemblyVotes(resp.Body, members)
-	log.WithContext(ctx).WithField("nyassembly", u).WithField("votes", len(votes)).Debugf("looking up NYAssembly votes %s-%s", session, printNo)
+	votes, err := parseAssemblyVotes(bytes.NewReader(body), resolver, a.logger())
+	a.logger().DebugContext(ctx, "looking up NYAssembly votes", "session", session, "printNo", printNo, "nyassembly", u, "votes", len(votes))
 	return votes, err
 }
 
-func parseAssemblyVotes(r io.Reader, members []MemberEntry) ([]BillVote, error) {
-	memberLookup := make(map[string]int)
-	for _, m := range members {
-		memberLookup[m.ShortName] = m.MemberID
-	}
+func parseAssemblyVotes(r io.Reader, resolver *MemberResolver, logger *slog.Logger) ([]BillVote, error) {
 	var out []BillVote
 	z := html.NewTokenizer(r)
-	var inTable, inCaption, dateNext, committeeNext bool
-	var text, dateStr, caption, commitee string
+	var inTable, inCaption, hasCaption, dateNext, committeeNext, chairNext bool
+	var text, dateStr, caption, commitee, chair string
 	var tokens []string
 
 	for {
@@ -75,16 +67,23 @@ func parseAssemblyVotes(r io.Reader, members []MemberEntry) ([]BillVote, error)
 				dateNext = true
 			case inCaption && tokenText == "Committee:":
 				committeeNext = true
-			case inCaption && committeeNext:
-				commitee, _, _ = strings.Cut(token.Data, "Chair:")
+			case inCaption && tokenText == "Chair:":
+				chairNext = true
+			case inCaption && committeeNext && tokenText != "":
+				// "Ways and Means Chair: Weinstein"
+				commitee, chair, _ = strings.Cut(token.Data, "Chair:")
 				commitee = strings.TrimSpace(commitee)
+				chair = strings.TrimSpace(chair)
 				committeeNext = false
-			case inCaption && dateNext:
+			case inCaption && chairNext && tokenText != "":
+				chair = tokenText
+				chairNext = false
+			case inCaption && dateNext && tokenText != "":
 				dt, err := time.Parse("01/02/2006", tokenText)
 				if err == nil {
 					dateStr = dt.Format("2006-01-02")
 				} else {
-					log.WithField("date", tokenText).Warnf("unable to parse %s", err)
+					logger.Warn("unable to parse vote date", "date", tokenText, "error", err)
 				}
 				dateNext = false
 			case inCaption:
@@ -95,13 +94,20 @@ func parseAssemblyVotes(r io.Reader, members []MemberEntry) ([]BillVote, error)
 			case "table":
 				inTable = true
 				inCaption = false
+				hasCaption = false
 				dateNext = false
 				committeeNext = false
+				chairNext = false
 				caption = ""
+				dateStr = ""
+				commitee = ""
+				chair = ""
+				tokens = nil
 			case "td":
 				text = ""
 			case "caption":
 				inCaption = true
+				hasCaption = true
 			}
 		case html.EndTagToken:
 			switch token.Data {
@@ -112,43 +118,59 @@ func parseAssemblyVotes(r io.Reader, members []MemberEntry) ([]BillVote, error)
 				}
 			case "table":
 				inTable = false
+				if !hasCaption {
+					// not a vote table
+					continue
+				}
 				_, action, _ := strings.Cut(caption, "Action:")
 				bv := BillVote{
 					VoteDate: dateStr,
-					VoteType: strings.TrimSpace(action), // Favorable refer to committee Ways and Means
+					VoteType: "FLOOR",
+					Outcome:  strings.TrimSpace(action), // Favorable refer to committee Ways and Means
+				}
+				if commitee != "" {
+					bv.VoteType = "COMMITTEE"
 				}
 				bv.Committee.Chamber = "ASSEMBLY"
 				bv.Committee.Name = commitee
+				bv.Committee.Chair = chair
 				mv := MemberVotes{}
 				for i := 0; i+1 < len(tokens); i += 2 {
-					shortName := strings.ToUpper(tokens[i])
+					match := resolver.Resolve(tokens[i])
+					entry := MemberEntry{
+						MemberID:  match.Member.MemberID,
+						Chamber:   bv.Committee.Chamber,
+						ShortName: strings.ToUpper(tokens[i]),
+						FullName:  match.Member.FullName,
+					}
+					var list *MemberEntryList
 					switch tokens[i+1] {
 					case "Y", "Aye":
-						mv.Aye.Items = append(mv.Aye.Items, MemberEntry{
-							MemberID:  memberLookup[shortName],
-							Chamber:   bv.Committee.Chamber,
-							ShortName: shortName,
-						})
+						list = &mv.Aye
 					case "N", "NO", "Nay":
-						mv.Nay.Items = append(mv.Nay.Items, MemberEntry{
-							MemberID:  memberLookup[shortName],
-							Chamber:   bv.Committee.Chamber,
-							ShortName: shortName,
-						})
+						list = &mv.Nay
 					case "ER", "Excused":
-						mv.Excused.Items = append(mv.Excused.Items, MemberEntry{
-							MemberID:  memberLookup[shortName],
-							Chamber:   bv.Committee.Chamber,
-							ShortName: shortName,
-						})
+						list = &mv.Excused
 					case "Absent":
-						mv.Absent.Items = append(mv.Absent.Items, MemberEntry{
-							MemberID:  memberLookup[shortName],
-							Chamber:   bv.Committee.Chamber,
-							ShortName: shortName,
-						})
+						list = &mv.Absent
+					case "ABD", "Abstain", "Abstained":
+						list = &mv.Abstained
 					default:
-						log.WithField("caption", caption).Infof("unkown td %q %q", tokens[i], tokens[i+1])
+						// keep the member on the roll call under the unknown code
+						logger.Info("unknown vote", "caption", caption, "member", tokens[i], "vote", tokens[i+1])
+						if mv.Other == nil {
+							mv.Other = make(map[string]MemberEntryList)
+						}
+						code := strings.ToUpper(tokens[i+1])
+						other := mv.Other[code]
+						other.Items = append(other.Items, entry)
+						mv.Other[code] = other
+					}
+					if list != nil {
+						list.Items = append(list.Items, entry)
+					}
+					if w, ok := match.Warning(tokens[i+1]); ok {
+						bv.Warnings = append(bv.Warnings, w)
 					}
 				}
 				bv.MemberVotes.Items = mv
@@ -160,5 +182,4 @@ func parseAssemblyVotes(r io.Reader, members []MemberEntry) ([]BillVote, error)
 			}
 		}
 	}
-	return out, nil
 }
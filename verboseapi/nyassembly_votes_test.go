@@ -2,7 +2,9 @@ package verboseapi
 
 import (
 	"context"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -21,13 +23,9 @@ func TestAssemblyVotes(t *testing.T) {
 	if len(assemblyVotes) != 4 {
 		t.Fatalf("expected 4 votes got %d", len(assemblyVotes))
 	}
-	bill := &Bill{}
-	bill.Votes.Items = assemblyVotes
-
-	votes := bill.GetVotes()
-	for i, v := range votes {
-		if v.MemberID == 0 {
-			t.Logf("[%d] unknown member %#v", i, v)
+	for _, v := range assemblyVotes {
+		for _, w := range v.Warnings {
+			t.Logf("%s %s %q %s candidates:%d", v.VoteDate, v.VoteType, w.Name, w.Reason, len(w.Candidates))
 		}
 	}
 
@@ -40,8 +38,11 @@ func TestAssemblyVotes(t *testing.T) {
 	if len(assemblyVotes) < 1 {
 		t.Fatalf("expected 1 votes got %d", len(assemblyVotes))
 	}
-	if assemblyVotes[0].VoteType != "Held for Consideration" {
-		t.Fatalf("expected Held for Consideration got %s", assemblyVotes[0].VoteType)
+	if assemblyVotes[0].VoteType != "COMMITTEE" {
+		t.Fatalf("expected COMMITTEE got %s", assemblyVotes[0].VoteType)
+	}
+	if assemblyVotes[0].Outcome != "Held for Consideration" {
+		t.Fatalf("expected Held for Consideration got %s", assemblyVotes[0].Outcome)
 	}
 	t.Logf("%#v", assemblyVotes[0])
 	date := assemblyVotes[0].VoteDate
@@ -50,3 +51,68 @@ func TestAssemblyVotes(t *testing.T) {
 	}
 
 }
+
+func TestParseAssemblyVotes(t *testing.T) {
+	type testCase struct {
+		name      string
+		body      string
+		voteType  string
+		outcome   string
+		committee string
+		chair     string
+		date      string
+	}
+	tests := []testCase{
+		{
+			name:     "floor",
+			body:     `<table><caption><span>DATE:</span> <span>06/02/2022</span> Assembly Vote YEA/NAY: 140/8</caption><tr><td>Glick</td><td>Y</td></tr></table>`,
+			voteType: "FLOOR",
+			date:     "2022-06-02",
+		},
+		{
+			name:      "committee",
+			body:      `<table><caption><span>Committee:</span> <span>Ways and Means Chair: Weinstein</span> <span>DATE:</span><span>05/17/2022</span> Action: Favorable refer to committee Rules</caption><tr><td>Glick</td><td>Aye</td></tr></table>`,
+			voteType:  "COMMITTEE",
+			outcome:   "Favorable refer to committee Rules",
+			committee: "Ways and Means",
+			chair:     "Weinstein",
+			date:      "2022-05-17",
+		},
+		{
+			name:      "separate chair",
+			body:      `<table><caption><span>Committee:</span> <span>Codes</span> <span>Chair:</span> <span>Lavine</span> <span>DATE:</span><span>05/14/2024</span> Action: Held for Consideration</caption><tr><td>Glick</td><td>Nay</td></tr></table>`,
+			voteType:  "COMMITTEE",
+			outcome:   "Held for Consideration",
+			committee: "Codes",
+			chair:     "Lavine",
+			date:      "2024-05-14",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			votes, err := parseAssemblyVotes(strings.NewReader(tc.body), NewMemberResolver(nil), slog.Default())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(votes) != 1 {
+				t.Fatalf("expected 1 vote got %d", len(votes))
+			}
+			v := votes[0]
+			if v.VoteType != tc.voteType {
+				t.Errorf("VoteType got %q expected %q", v.VoteType, tc.voteType)
+			}
+			if v.Outcome != tc.outcome {
+				t.Errorf("Outcome got %q expected %q", v.Outcome, tc.outcome)
+			}
+			if v.Committee.Name != tc.committee {
+				t.Errorf("Committee got %q expected %q", v.Committee.Name, tc.committee)
+			}
+			if v.Committee.Chair != tc.chair {
+				t.Errorf("Chair got %q expected %q", v.Committee.Chair, tc.chair)
+			}
+			if v.VoteDate != tc.date {
+				t.Errorf("VoteDate got %q expected %q", v.VoteDate, tc.date)
+			}
+		})
+	}
+}
@@ -3,33 +3,67 @@ package verboseapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
 
 const apiDomain = "https://legislation.nysenate.gov"
 
+// ErrNotFound is wrapped in the error returned for an OpenLegislation 404 response
+var ErrNotFound = errors.New("not found")
+
 func NewAPI(token string) *NYSenateAPI {
 	if token == "" {
 		panic("missing token")
 	}
 	return &NYSenateAPI{
-		token:     token,
-		UserAgent: "https://github.com/jehiah/nysenateapi",
-		Limiter:   rate.NewLimiter(rate.Every(5*time.Millisecond), 25),
+		token:           token,
+		BaseURL:         apiDomain,
+		UserAgent:       "https://github.com/jehiah/nysenateapi",
+		Limiter:         rate.NewLimiter(rate.Every(5*time.Millisecond), 25),
+		AssemblyLimiter: rate.NewLimiter(DefaultAssemblyLimit, 1),
+		robots:          &sync.Once{},
+		stats:           &waitStats{},
+		drift:           &driftStats{},
 	}
 }
 
 type NYSenateAPI struct {
-	token     string
+	token string
+	// BaseURL is the OpenLegislation API (default https://legislation.nysenate.gov)
+	BaseURL   string
 	UserAgent string
 
-	// Limiter throttles requests to the API
+	// Limiter throttles requests to the OpenLegislation API
 	Limiter *rate.Limiter
+	// AssemblyLimiter throttles requests to nyassembly.gov (default DefaultAssemblyLimit)
+	AssemblyLimiter *rate.Limiter
+	// RespectRobots slows AssemblyLimiter to the nyassembly.gov robots.txt Crawl-delay
+	RespectRobots bool
+	// Adaptive and AssemblyAdaptive, when set, adjust the rate of Limiter and AssemblyLimiter
+	// as the server throttles requests. i.e. a.Adaptive = NewAdaptiveLimiter(a.Limiter)
+	Adaptive         *AdaptiveLimiter
+	AssemblyAdaptive *AdaptiveLimiter
+	// Observer is notified of every request for metrics or tracing (default NoopObserver)
+	Observer Observer
+	// Logger for debug logging of requests (default slog.Default())
+	Logger *slog.Logger
+	// Strict records OpenLegislation response fields that are unknown or don't match the verboseapi types.
+	// See DriftReport
+	Strict bool
+
+	robots *sync.Once
+	stats  *waitStats
+	drift  *driftStats
 }
 
 type Chamber string
@@ -37,26 +71,96 @@ type Chamber string
 const SenateChamber Chamber = "senate"
 const AssemblyChamber Chamber = "assembly"
 
-func (a NYSenateAPI) get(ctx context.Context, path string, params *url.Values, v interface{}) error {
-	err := a.Limiter.Wait(ctx)
+func (a NYSenateAPI) get(ctx context.Context, endpoint, path string, params *url.Values, v interface{}) (err error) {
+	u := a.BaseURL + path
+	err = a.wait(ctx, u)
 	if err != nil {
 		return err
 	}
 	if params == nil {
 		params = &url.Values{}
 	}
+	a.logger().DebugContext(ctx, "NYSenateAPI.get", "nysenate_api", u+"?"+params.Encode())
 	params.Set("key", a.token)
-	u := apiDomain + path
-	log.WithContext(ctx).WithField("nysenate_api", u+"?"+params.Encode()).Debug("NYSenateAPI.get")
+
+	info := RequestInfo{Endpoint: endpoint, Host: hostOf(u), Method: "GET", URL: u}
+	var result RequestResult
+	ctx = a.observer().RequestStart(ctx, info)
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		result.Err = err
+		a.observer().RequestEnd(ctx, info, result)
+	}()
+
 	req, err := http.NewRequestWithContext(ctx, "GET", u+"?"+params.Encode(), nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("User-Agent", a.UserAgent)
 	resp, err := http.DefaultClient.Do(req)
+	a.observe(u, resp, time.Since(start))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(&v)
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s returned %s: %w", u, resp.Status, ErrNotFound)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+	body := &countingReader{Reader: resp.Body}
+	if !a.Strict {
+		err = json.NewDecoder(body).Decode(&v)
+		result.Bytes = body.n
+		return err
+	}
+	b, err := io.ReadAll(body)
+	result.Bytes = body.n
+	if err != nil {
+		return err
+	}
+	a.checkDrift(ctx, endpoint, b, v)
+	return json.Unmarshal(b, &v)
+}
+
+// getHTML fetches a nyassembly.gov page
+func (a NYSenateAPI) getHTML(ctx context.Context, endpoint, u string) (b []byte, err error) {
+	err = a.wait(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	info := RequestInfo{Endpoint: endpoint, Host: hostOf(u), Method: "GET", URL: u}
+	if i := strings.Index(u, "?"); i != -1 {
+		info.URL = u[:i]
+	}
+	var result RequestResult
+	ctx = a.observer().RequestStart(ctx, info)
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		result.Bytes = int64(len(b))
+		result.Err = err
+		a.observer().RequestEnd(ctx, info, result)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", a.UserAgent)
+	resp, err := http.DefaultClient.Do(req)
+	a.observe(u, resp, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
 }
@@ -0,0 +1,76 @@
+package verboseapi
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// RequestInfo describes a request to the OpenLegislation API or nyassembly.gov
+type RequestInfo struct {
+	// Endpoint is the API method making the request (i.e. "GetBill", "AssemblyVotes")
+	Endpoint string
+	Host     string
+	Method   string
+	// URL is the request URL without query parameters
+	URL string
+}
+
+// RequestResult is the outcome of a request
+type RequestResult struct {
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	// Duration is the time to send the request and read (or decode) the response body
+	Duration time.Duration
+	// Bytes is the size of the response body read
+	Bytes int64
+	Err   error
+}
+
+// Observer is notified at the start and end of every request. RequestStart returns the context used for the
+// request which is also passed to RequestEnd.
+type Observer interface {
+	RequestStart(ctx context.Context, info RequestInfo) context.Context
+	RequestEnd(ctx context.Context, info RequestInfo, result RequestResult)
+}
+
+// NoopObserver is an Observer that does nothing
+type NoopObserver struct{}
+
+func (NoopObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context     { return ctx }
+func (NoopObserver) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {}
+
+// MultiObserver notifies each Observer in order
+type MultiObserver []Observer
+
+func (m MultiObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, o := range m {
+		ctx = o.RequestStart(ctx, info)
+	}
+	return ctx
+}
+
+func (m MultiObserver) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].RequestEnd(ctx, info, result)
+	}
+}
+
+func (a NYSenateAPI) observer() Observer {
+	if a.Observer == nil {
+		return NoopObserver{}
+	}
+	return a.Observer
+}
+
+// countingReader counts bytes read from a response body
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
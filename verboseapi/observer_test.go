@@ -0,0 +1,163 @@
+package verboseapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type recordingObserver struct {
+	sync.Mutex
+	started []RequestInfo
+	results []RequestResult
+}
+
+func (r *recordingObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	r.Lock()
+	defer r.Unlock()
+	r.started = append(r.started, info)
+	return ctx
+}
+
+func (r *recordingObserver) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	r.Lock()
+	defer r.Unlock()
+	r.results = append(r.results, result)
+}
+
+func TestObserver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("oops"))
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer ts.Close()
+
+	o := &recordingObserver{}
+	a := NewAPI("token")
+	a.BaseURL = ts.URL
+	a.Limiter = rate.NewLimiter(rate.Inf, 1)
+	a.Observer = o
+
+	var v struct {
+		Success bool `json:"success"`
+	}
+	if err := a.get(context.Background(), "Bills", "/api/3/bills/2023", nil, &v); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.getHTML(context.Background(), "AssemblyBill", ts.URL+"/error?bn=A1"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	expected := []RequestInfo{
+		{Endpoint: "Bills", Host: hostOf(ts.URL), Method: "GET", URL: ts.URL + "/api/3/bills/2023"},
+		{Endpoint: "AssemblyBill", Host: hostOf(ts.URL), Method: "GET", URL: ts.URL + "/error"},
+	}
+	if !reflect.DeepEqual(o.started, expected) {
+		t.Errorf("got %#v", o.started)
+	}
+	if len(o.results) != 2 {
+		t.Fatalf("got %d results", len(o.results))
+	}
+	if r := o.results[0]; r.StatusCode != 200 || r.Bytes != 16 || r.Err != nil || r.Duration <= 0 {
+		t.Errorf("unexpected result %#v", r)
+	}
+	if r := o.results[1]; r.StatusCode != 500 || r.Err == nil {
+		t.Errorf("unexpected result %#v", r)
+	}
+}
+
+func TestObserverDurationIncludesBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":`))
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`true}`))
+	}))
+	defer ts.Close()
+
+	o := &recordingObserver{}
+	a := NewAPI("token")
+	a.BaseURL = ts.URL
+	a.Limiter = rate.NewLimiter(rate.Inf, 1)
+	a.Observer = o
+	var v struct {
+		Success bool `json:"success"`
+	}
+	if err := a.get(context.Background(), "Bills", "/api/3/bills/2023", nil, &v); err != nil {
+		t.Fatal(err)
+	}
+	if d := o.results[0].Duration; d < 50*time.Millisecond {
+		t.Errorf("got duration %v expected it to include reading the body", d)
+	}
+}
+
+func TestMetricsObserver(t *testing.T) {
+	counts := make(map[string]int)
+	var durations []float64
+	m := MetricsObserver{
+		Requests: func(labels ...string) { counts["requests "+labels[0]+" "+labels[1]+" "+labels[2]]++ },
+		Errors:   func(labels ...string) { counts["errors "+labels[0]+" "+labels[2]]++ },
+		Duration: func(seconds float64, labels ...string) { durations = append(durations, seconds) },
+	}
+	info := RequestInfo{Endpoint: "GetBill", Host: OpenLegislationHost}
+	ctx := m.RequestStart(context.Background(), info)
+	m.RequestEnd(ctx, info, RequestResult{StatusCode: 200, Duration: 1500000000})
+	m.RequestEnd(ctx, info, RequestResult{Err: errors.New("timeout")})
+
+	expected := map[string]int{
+		"requests GetBill legislation.nysenate.gov 200":   1,
+		"requests GetBill legislation.nysenate.gov error": 1,
+		"errors GetBill error":                            1,
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("got %#v", counts)
+	}
+	if !reflect.DeepEqual(durations, []float64{1.5, 0}) {
+		t.Errorf("got durations %v", durations)
+	}
+}
+
+func TestTracingObserver(t *testing.T) {
+	var name string
+	var start, end []Attribute
+	var endErr error
+	tr := TracingObserver{
+		Start: func(ctx context.Context, n string, attrs []Attribute) (context.Context, EndSpan) {
+			name, start = n, attrs
+			return ctx, func(attrs []Attribute, err error) { end, endErr = attrs, err }
+		},
+	}
+	o := MultiObserver{NoopObserver{}, tr}
+	info := RequestInfo{Endpoint: "AssemblyVotes", Host: AssemblyHost, Method: "GET", URL: "https://nyassembly.gov/leg/"}
+	ctx := o.RequestStart(context.Background(), info)
+	err := errors.New("nyassembly.gov returned 503")
+	o.RequestEnd(ctx, info, RequestResult{StatusCode: 503, Bytes: 10, Err: err})
+
+	if name != "NYSenateAPI.AssemblyVotes" {
+		t.Errorf("got span name %q", name)
+	}
+	if len(start) != 4 || start[1] != (Attribute{"server.address", AssemblyHost}) {
+		t.Errorf("got start attributes %#v", start)
+	}
+	expected := []Attribute{
+		{"http.response.body.size", int64(10)},
+		{"http.response.status_code", int64(503)},
+	}
+	if !reflect.DeepEqual(end, expected) {
+		t.Errorf("got end attributes %#v", end)
+	}
+	if endErr != err {
+		t.Errorf("got error %v", endErr)
+	}
+}
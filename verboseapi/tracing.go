@@ -0,0 +1,63 @@
+package verboseapi
+
+import (
+	"context"
+)
+
+// Attribute is a span attribute; Value is a string or int64
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// EndSpan records the attributes and error of a request and ends its span
+type EndSpan func(attrs []Attribute, err error)
+
+// TracingObserver is an Observer that creates a span for each request named "NYSenateAPI.<Endpoint>" with
+// OpenTelemetry semantic convention attributes. i.e. with go.opentelemetry.io/otel
+//
+//	a.Observer = verboseapi.TracingObserver{
+//		Start: func(ctx context.Context, name string, attrs []verboseapi.Attribute) (context.Context, verboseapi.EndSpan) {
+//			ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(toOtel(attrs)...))
+//			return ctx, func(attrs []verboseapi.Attribute, err error) {
+//				span.SetAttributes(toOtel(attrs)...)
+//				if err != nil {
+//					span.RecordError(err)
+//					span.SetStatus(codes.Error, err.Error())
+//				}
+//				span.End()
+//			}
+//		},
+//	}
+type TracingObserver struct {
+	Start func(ctx context.Context, name string, attrs []Attribute) (context.Context, EndSpan)
+}
+
+type endSpanKey struct{}
+
+func (t TracingObserver) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	if t.Start == nil {
+		return ctx
+	}
+	ctx, end := t.Start(ctx, "NYSenateAPI."+info.Endpoint, []Attribute{
+		{"http.request.method", info.Method},
+		{"server.address", info.Host},
+		{"url.full", info.URL},
+		{"nysenateapi.endpoint", info.Endpoint},
+	})
+	return context.WithValue(ctx, endSpanKey{}, end)
+}
+
+func (t TracingObserver) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	end, ok := ctx.Value(endSpanKey{}).(EndSpan)
+	if !ok || end == nil {
+		return
+	}
+	attrs := []Attribute{
+		{"http.response.body.size", result.Bytes},
+	}
+	if result.StatusCode != 0 {
+		attrs = append(attrs, Attribute{"http.response.status_code", int64(result.StatusCode)})
+	}
+	end(attrs, result.Err)
+}
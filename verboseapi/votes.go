@@ -1,12 +1,18 @@
 package verboseapi
 
+import "fmt"
+
 type VoteEntry struct {
 	MemberID  int
 	Chamber   string
 	VoteType  string // COMMITTEE, FLOOR
-	Vote      string // Aye, Nay, Excused
-	ShortName string
-	FullName  string
+	Vote      string // Aye, Nay, Excused, Absent, Abstained
+	Qualifier string // i.e. "with reservations"
+	// Version is the amendment voted on; ActiveVersion is set if it is the active amendment
+	Version       string
+	ActiveVersion bool
+	ShortName     string
+	FullName      string
 }
 type VoteEntries []VoteEntry
 
@@ -20,66 +26,77 @@ func (v VoteEntries) Filter(chamber string) VoteEntries {
 	return o
 }
 
+// VoteCodeDescription returns the vote and qualifier for an OpenLegislation vote code.
+// Unknown codes are returned unchanged.
+func VoteCodeDescription(code string) (vote, qualifier string) {
+	switch code {
+	case "AYE":
+		return "Aye", ""
+	case "AYEWR":
+		return "Aye", "with reservations"
+	case "NAY":
+		return "Nay", ""
+	case "EXC":
+		return "Excused", ""
+	case "ABS":
+		return "Absent", ""
+	case "ABD":
+		return "Abstained", ""
+	}
+	return code, ""
+}
+
+// GetVotes returns member votes on the active amendment and votes without a version
+// (i.e. on the original print or scraped from nyassembly.gov)
 func (b Bill) GetVotes() VoteEntries {
+	return b.getVotes(false)
+}
+
+// GetAllVotes returns member votes on all amendments tagged with the Version voted on
+// and if it was the ActiveVersion
+func (b Bill) GetAllVotes() VoteEntries {
+	return b.getVotes(true)
+}
+
+// IsActiveVote reports if a vote was on the active amendment. Votes scraped from nyassembly.gov
+// don't have a version and are assumed to be on the active amendment.
+func (b Bill) IsActiveVote(v BillVote) bool {
+	return v.Version == b.ActiveVersion || (v.Version == "" && v.Committee.Chamber == "ASSEMBLY")
+}
+
+func (b Bill) getVotes(allVersions bool) VoteEntries {
 	var o VoteEntries
-	// TODO: dedupe
 	for _, v := range b.Votes.Items {
-		if v.Version != b.ActiveVersion && v.Version != "" {
-			// Assembly workaround Votes don't have version
-			// TODO: all versions?
+		active := b.IsActiveVote(v)
+		if !allVersions && v.Version != b.ActiveVersion && v.Version != "" {
 			continue
 		}
-		for _, m := range v.MemberVotes.Items.Excused.Items {
-			o = append(o, VoteEntry{
-				ShortName: m.ShortName,
-				MemberID:  m.MemberID,
-				Chamber:   m.Chamber,
-				VoteType:  v.VoteType,
-				Vote:      "Excused",
-			})
-		}
-		for _, m := range v.MemberVotes.Items.Aye.Items {
-			o = append(o, VoteEntry{
-				ShortName: m.ShortName,
-				FullName:  m.FullName,
-				MemberID:  m.MemberID,
-				Chamber:   m.Chamber,
-				VoteType:  v.VoteType,
-				Vote:      "Aye",
-			})
-		}
-		for _, m := range v.MemberVotes.Items.Nay.Items {
-			o = append(o, VoteEntry{
-				ShortName: m.ShortName,
-				FullName:  m.FullName,
-				MemberID:  m.MemberID,
-				Chamber:   m.Chamber,
-				VoteType:  v.VoteType,
-				Vote:      "Nay",
-			})
-		}
-		for _, m := range v.MemberVotes.Items.AyeWithReservations.Items {
-			o = append(o, VoteEntry{
-				ShortName: m.ShortName,
-				FullName:  m.FullName,
-				MemberID:  m.MemberID,
-				Chamber:   m.Chamber,
-				VoteType:  v.VoteType,
-				Vote:      "Aye",
-				// TODO: add note "with reservations"
-			})
-		}
-		for _, m := range v.MemberVotes.Items.Absent.Items {
-			o = append(o, VoteEntry{
-				ShortName: m.ShortName,
-				FullName:  m.FullName,
-				MemberID:  m.MemberID,
-				Chamber:   m.Chamber,
-				VoteType:  v.VoteType,
-				Vote:      "Absent",
-			})
+		// a member can be listed more than once i.e. under multiple short names
+		seen := make(map[string]bool)
+		for _, c := range v.MemberVotes.Items.Codes() {
+			vote, qualifier := VoteCodeDescription(c.Code)
+			for _, m := range c.Members {
+				key := m.ShortName
+				if m.MemberID > 0 {
+					key = fmt.Sprintf("%d", m.MemberID)
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				o = append(o, VoteEntry{
+					ShortName:     m.ShortName,
+					FullName:      m.FullName,
+					MemberID:      m.MemberID,
+					Chamber:       m.Chamber,
+					VoteType:      v.VoteType,
+					Vote:          vote,
+					Qualifier:     qualifier,
+					Version:       v.Version,
+					ActiveVersion: active,
+				})
+			}
 		}
-		// TODO: Abstained ?
 	}
 	return o
 }
@@ -0,0 +1,85 @@
+package verboseapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMemberVotesJSON(t *testing.T) {
+	var v BillVote
+	err := json.Unmarshal([]byte(`{"version":"","voteType":"FLOOR","voteDate":"2023-06-07","memberVotes":{"items":{
+		"AYE":{"items":[{"memberId":1,"shortName":"A"}],"size":1},
+		"AYEWR":{"items":[{"memberId":2,"shortName":"B"}],"size":1},
+		"ABS":{"items":[{"memberId":3,"shortName":"C"}],"size":1},
+		"ABD":{"items":[{"memberId":4,"shortName":"D"}],"size":1},
+		"XYZ":{"items":[{"memberId":5,"shortName":"E"}],"size":1}
+	},"size":5}}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mv := v.MemberVotes.Items
+	if len(mv.Absent.Items) != 1 || len(mv.Abstained.Items) != 1 || len(mv.AyeWithReservations.Items) != 1 {
+		t.Fatalf("unexpected %#v", mv)
+	}
+	if len(mv.Other["XYZ"].Items) != 1 {
+		t.Fatalf("expected unknown vote code XYZ to be kept %#v", mv.Other)
+	}
+
+	bill := &Bill{}
+	bill.Votes.Items = []BillVote{v}
+	votes := bill.GetVotes()
+	if len(votes) != 5 {
+		t.Fatalf("expected 5 votes got %d", len(votes))
+	}
+	expected := []struct{ vote, qualifier string }{
+		{"Aye", ""}, {"Aye", "with reservations"}, {"Absent", ""}, {"Abstained", ""}, {"XYZ", ""},
+	}
+	for i, e := range expected {
+		if votes[i].Vote != e.vote || votes[i].Qualifier != e.qualifier {
+			t.Errorf("[%d] got %q %q expected %q %q", i, votes[i].Vote, votes[i].Qualifier, e.vote, e.qualifier)
+		}
+	}
+
+	// round trip
+	b, err := json.Marshal(mv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mv2 MemberVotes
+	if err := json.Unmarshal(b, &mv2); err != nil {
+		t.Fatal(err)
+	}
+	if len(mv2.Codes()) != 5 {
+		t.Fatalf("expected 5 codes got %#v", mv2.Codes())
+	}
+}
+
+func TestGetAllVotes(t *testing.T) {
+	bill := &Bill{ActiveVersion: "A"}
+	original := BillVote{Version: "", VoteType: "COMMITTEE"}
+	original.MemberVotes.Items.Aye.Items = []MemberEntry{{MemberID: 1}}
+	amended := BillVote{Version: "A", VoteType: "FLOOR"}
+	amended.MemberVotes.Items.Nay.Items = []MemberEntry{{MemberID: 1}}
+	assembly := BillVote{VoteType: "COMMITTEE", Outcome: "Held for Consideration"}
+	assembly.Committee.Chamber = "ASSEMBLY"
+	assembly.MemberVotes.Items.Aye.Items = []MemberEntry{{MemberID: 2}}
+	bill.Votes.Items = []BillVote{original, amended, assembly}
+
+	// votes without a version are always included
+	if votes := bill.GetVotes(); len(votes) != 3 {
+		t.Fatalf("expected 3 votes got %#v", votes)
+	}
+	bill.Votes.Items = append(bill.Votes.Items, BillVote{Version: "B", VoteType: "COMMITTEE"})
+	bill.Votes.Items[3].MemberVotes.Items.Aye.Items = []MemberEntry{{MemberID: 3}}
+	if votes := bill.GetVotes(); len(votes) != 3 {
+		t.Fatalf("expected votes on other versions to be excluded %#v", votes)
+	}
+	bill.Votes.Items = bill.Votes.Items[:3]
+	votes := bill.GetAllVotes()
+	if len(votes) != 3 {
+		t.Fatalf("expected 3 votes got %#v", votes)
+	}
+	if votes[0].ActiveVersion || votes[0].Version != "" || !votes[1].ActiveVersion || !votes[2].ActiveVersion {
+		t.Errorf("unexpected versions %#v", votes)
+	}
+}